@@ -0,0 +1,331 @@
+package mandelbrot
+
+import (
+	"math"
+	"math/bits"
+)
+
+// FuncBatch represents a function that computes the Mandelbrot set for several points.
+//
+// It writes the result of cs[i] to out[i].
+// out must be at least as long as cs.
+type FuncBatch func(cs []complex128, out []Result)
+
+// Batch returns a new [FuncBatch] that calls f for each point.
+func (f Func) Batch() FuncBatch {
+	return func(cs []complex128, out []Result) {
+		out = out[:len(cs)]
+		for i, c := range cs {
+			out[i] = f(c)
+		}
+	}
+}
+
+// NewBatch returns a new [FuncBatch].
+//
+// It returns the same results as [New], but iterates several points in lockstep, which is faster.
+func NewBatch(maxIter int) FuncBatch {
+	return NewPowBatch(maxIter, 2)
+}
+
+// NewPowBatch returns a new [FuncBatch] that uses the given power.
+//
+// It returns the same results as [NewPow].
+// The integer powers from 2 to 65536 are iterated in lockstep, the other powers call the [Func] for each point.
+func NewPowBatch(maxIter int, pow float64) FuncBatch {
+	if pow < 2 || pow != math.Trunc(pow) || pow > maxPowInt {
+		return NewPow(maxIter, pow).Batch()
+	}
+	n := int(pow)
+	skip := inPow2Skip
+	if n >= 3 {
+		skip = newMainComponent(n).contains
+	}
+	powFunc := getPow(pow)
+	trailingZeros := bits.TrailingZeros(uint(n))
+	return func(cs []complex128, out []Result) {
+		b := powBatch{
+			maxIter:       maxIter,
+			n:             n,
+			trailingZeros: trailingZeros,
+			rest:          uint(n) >> (trailingZeros + 1),
+			pow:           powFunc,
+			skip:          skip,
+		}
+		b.iterate(cs, out)
+	}
+}
+
+const (
+	// batchLanes is the number of points iterated in lockstep.
+	batchLanes = 8
+	// batchChunk is the number of iterations executed without escape test.
+	batchChunk = 4
+	// batchWarmup is the number of iterations computed directly, before a point is loaded in a lane.
+	batchWarmup = 16
+)
+
+// powBatch contains the state of the points iterated in lockstep.
+//
+// It uses a structure of arrays, so the iterations of the different points are independent, and can be executed in parallel by the CPU.
+type powBatch struct {
+	zr, zi, cr, ci [batchLanes]float64
+	iter           [batchLanes]int
+	// index is the index of the point in the batch, or -1 if the lane is empty.
+	index [batchLanes]int
+
+	maxIter int
+	// n is the integer power.
+	n             int
+	trailingZeros int
+	rest          uint
+	pow           func(complex128) complex128
+	// skip returns true if the point is in the main component (or the period-2 bulb for the power 2).
+	skip func(c complex128) bool
+}
+
+// iterate computes the points in lockstep.
+//
+// The lanes are iterated by chunks, without escape test.
+// If a point escaped during a chunk, it is computed again from the beginning of the chunk, with the escape test.
+// It works because an escaped orbit never comes back, and it gives exactly the same results as [iterPow2] and [iterPow].
+func (b *powBatch) iterate(cs []complex128, out []Result) {
+	out = out[:len(cs)]
+	next := 0
+	for l := range batchLanes {
+		b.index[l] = -1
+	}
+	for {
+		next = b.fill(cs, out, next)
+		if !b.active() {
+			return
+		}
+		var zr, zi [batchLanes]float64
+		switch b.n {
+		case 2:
+			zr, zi = b.chunkPow2()
+		case 3:
+			zr, zi = b.chunkPow3()
+		case 4:
+			zr, zi = b.chunkPow4()
+		default:
+			zr, zi = b.chunk()
+		}
+		b.update(zr, zi, out)
+	}
+}
+
+// fill loads the next points in the empty lanes, and returns the index of the next point to load.
+//
+// The points that escape during the warmup, or with less than a chunk of remaining iterations, are computed directly.
+func (b *powBatch) fill(cs []complex128, out []Result, next int) int {
+	maxIter := b.maxIter
+	for l := range batchLanes {
+		if b.index[l] >= 0 {
+			if maxIter-b.iter[l] >= batchChunk {
+				continue
+			}
+			b.finish(l, out)
+		}
+		for ; next < len(cs); next++ {
+			c := cs[next]
+			// optimization: skip main component
+			if b.skip(c) {
+				out[next] = Result{
+					Bounded: true,
+					Iter:    maxIter,
+				}
+				continue
+			}
+			// The first iterations are computed directly, because most points escape quickly.
+			z, iter := b.warmup(c)
+			if iter < batchWarmup || maxIter-iter < batchChunk {
+				b.iterFrom(&out[next], z, c, iter)
+				continue
+			}
+			b.zr[l], b.zi[l] = real(z), imag(z)
+			b.cr[l], b.ci[l] = real(c), imag(c)
+			b.iter[l] = iter
+			b.index[l] = next
+			next++
+			break
+		}
+	}
+	return next
+}
+
+// active returns true if at least one lane is not empty.
+func (b *powBatch) active() bool {
+	for l := range batchLanes {
+		if b.index[l] >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// warmup computes the first iterations of the point, until it escapes.
+func (b *powBatch) warmup(c complex128) (z complex128, iter int) {
+	z = c
+	warmup := min(batchWarmup, b.maxIter)
+	if b.n == 2 {
+		for ; iter < warmup && real(z)*real(z)+imag(z)*imag(z) <= bailoutSquare; iter++ {
+			z = z*z + c
+		}
+		return z, iter
+	}
+	for ; iter < warmup && real(z)*real(z)+imag(z)*imag(z) <= bailoutSquare; iter++ {
+		z = b.pow(z) + c
+	}
+	return z, iter
+}
+
+// iterFrom computes the remaining iterations of the point, from the given iteration, and writes the result.
+func (b *powBatch) iterFrom(res *Result, z, c complex128, iter int) {
+	if b.n == 2 {
+		*res = iterPow2From(z, c, iter, b.maxIter)
+		return
+	}
+	*res = iterPowFrom(z, c, iter, b.maxIter, b.pow)
+}
+
+// chunkPow2 returns the values of all lanes after a chunk of iterations of the power 2, without escape test.
+//
+// The empty lanes are iterated too, and their values are ignored.
+func (b *powBatch) chunkPow2() (zr, zi [batchLanes]float64) {
+	zr, zi = b.zr, b.zi
+	cr, ci := b.cr, b.ci
+	for range batchChunk {
+		for l := range batchLanes {
+			r, i := zr[l], zi[l]
+			zr[l] = r*r - i*i + cr[l]
+			zi[l] = r*i + i*r + ci[l]
+		}
+	}
+	return zr, zi
+}
+
+// chunkPow3 is like [powBatch.chunkPow2] for the power 3: z*z*z, see [pow3].
+func (b *powBatch) chunkPow3() (zr, zi [batchLanes]float64) {
+	zr, zi = b.zr, b.zi
+	cr, ci := b.cr, b.ci
+	for range batchChunk {
+		for l := range batchLanes {
+			r, i := zr[l], zi[l]
+			r2, i2 := r*r-i*i, r*i+i*r
+			zr[l] = r2*r - i2*i + cr[l]
+			zi[l] = r2*i + i2*r + ci[l]
+		}
+	}
+	return zr, zi
+}
+
+// chunkPow4 is like [powBatch.chunkPow2] for the power 4: (z*z)*(z*z), see [pow4].
+func (b *powBatch) chunkPow4() (zr, zi [batchLanes]float64) {
+	zr, zi = b.zr, b.zi
+	cr, ci := b.cr, b.ci
+	for range batchChunk {
+		for l := range batchLanes {
+			r, i := zr[l], zi[l]
+			r2, i2 := r*r-i*i, r*i+i*r
+			zr[l] = r2*r2 - i2*i2 + cr[l]
+			zi[l] = r2*i2 + i2*r2 + ci[l]
+		}
+	}
+	return zr, zi
+}
+
+// chunk is like [powBatch.chunkPow2] for any integer power.
+//
+// z^n is computed with exponentiation by squaring, in the same order as [newPowInt], so the results are exactly the same.
+func (b *powBatch) chunk() (zr, zi [batchLanes]float64) {
+	zr, zi = b.zr, b.zi
+	cr, ci := b.cr, b.ci
+	trailingZeros, rest := b.trailingZeros, b.rest
+	for range batchChunk {
+		for l := range batchLanes {
+			r, i := zr[l], zi[l]
+			for range trailingZeros {
+				r, i = r*r-i*i, r*i+i*r
+			}
+			resr, resi := r, i
+			for m := rest; m != 0; m >>= 1 {
+				r, i = r*r-i*i, r*i+i*r
+				if m&1 != 0 {
+					resr, resi = resr*r-resi*i, resr*i+resi*r
+				}
+			}
+			zr[l] = resr + cr[l]
+			zi[l] = resi + ci[l]
+		}
+	}
+	return zr, zi
+}
+
+// update updates the lanes with the values after a chunk.
+//
+// If a point escaped during the chunk, it is computed from the beginning of the chunk, and its lane becomes empty.
+func (b *powBatch) update(zr, zi [batchLanes]float64, out []Result) {
+	for l := range batchLanes {
+		if b.index[l] < 0 {
+			continue
+		}
+		// The value is NaN if it overflowed.
+		if !(zr[l]*zr[l]+zi[l]*zi[l] <= bailoutSquare) {
+			b.finish(l, out)
+			continue
+		}
+		b.zr[l], b.zi[l] = zr[l], zi[l]
+		b.iter[l] += batchChunk
+	}
+}
+
+// finish computes the remaining iterations of the lane, writes the result, and marks the lane as empty.
+func (b *powBatch) finish(l int, out []Result) {
+	z := complex(b.zr[l], b.zi[l])
+	c := complex(b.cr[l], b.ci[l])
+	b.iterFrom(&out[b.index[l]], z, c, b.iter[l])
+	b.index[l] = -1
+}
+
+// iterPow2From is like [iterPow2] without periodicity, but starts at the given iteration.
+func iterPow2From(z, c complex128, iter int, maxIter int) Result {
+	for ; iter < maxIter; iter++ {
+		// optimization: calculate "abs square" instead of "abs"
+		absSquare := real(z)*real(z) + imag(z)*imag(z)
+		if absSquare > bailoutSquare {
+			abs := math.Sqrt(absSquare)
+			return Result{
+				Bounded: false,
+				Iter:    iter,
+				Abs:     abs,
+			}
+		}
+		z = z*z + c
+	}
+	return Result{
+		Bounded: true,
+		Iter:    maxIter,
+	}
+}
+
+// iterPowFrom is like [iterPow] without periodicity, but starts at the given iteration.
+func iterPowFrom(z, c complex128, iter int, maxIter int, pow func(complex128) complex128) Result {
+	for ; iter < maxIter; iter++ {
+		// optimization: calculate "abs square" instead of "abs"
+		absSquare := real(z)*real(z) + imag(z)*imag(z)
+		if absSquare > bailoutSquare {
+			abs := math.Sqrt(absSquare)
+			return Result{
+				Bounded: false,
+				Iter:    iter,
+				Abs:     abs,
+			}
+		}
+		z = pow(z) + c
+	}
+	return Result{
+		Bounded: true,
+		Iter:    maxIter,
+	}
+}
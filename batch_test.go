@@ -0,0 +1,52 @@
+package mandelbrot
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/pierrre/assert"
+)
+
+func TestPowBatch(t *testing.T) {
+	cs := make([]complex128, 0, 64*64)
+	for y := range 64 {
+		for x := range 64 {
+			cs = append(cs, complex(-2.1+float64(x)*2.6/64, -1.3+float64(y)*2.6/64))
+		}
+	}
+	out := make([]Result, len(cs))
+	for _, pow := range []float64{2, 3, 4, 5, 8, 2.5} {
+		t.Run(strconv.FormatFloat(pow, 'f', -1, 64), func(t *testing.T) {
+			for _, maxIter := range []int{0, 1, 2, 3, 4, 5, 15, 16, 17, 19, 20, 21, 100, 257, 1000} {
+				f := NewPow(maxIter, pow)
+				NewPowBatch(maxIter, pow)(cs, out)
+				for i, c := range cs {
+					assert.Equal(t, out[i], f(c), assert.MessageWrapf("max iter %d, point %v", maxIter, c))
+				}
+			}
+		})
+	}
+}
+
+func TestBatch(t *testing.T) {
+	cs := []complex128{0, complex(-0.75, 0.1), complex(0.3, 0.5), 2}
+	out := make([]Result, len(cs))
+	NewBatch(100)(cs, out)
+	f := New(100)
+	for i, c := range cs {
+		assert.Equal(t, out[i], f(c))
+	}
+}
+
+func TestFuncBatch(t *testing.T) {
+	cs := []complex128{0, complex(-0.75, 0.1), complex(0.3, 0.5), 2}
+	out := make([]Result, len(cs))
+	f := NewWithOptions(Options{
+		MaxIter:  100,
+		Distance: true,
+	})
+	f.Batch()(cs, out)
+	for i, c := range cs {
+		assert.Equal(t, out[i], f(c))
+	}
+}
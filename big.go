@@ -0,0 +1,99 @@
+package mandelbrot
+
+import (
+	"math"
+	"math/big"
+)
+
+// BigFunc represents a function that computes the Mandelbrot set for a given arbitrary-precision point.
+type BigFunc func(re, im *big.Float) Result
+
+// WithSmooth returns a new [BigFunc] that sets [Result.Smooth] for the unbounded points.
+func (f BigFunc) WithSmooth() BigFunc {
+	return func(re, im *big.Float) Result {
+		return withSmooth(f(re, im), math.Ln2)
+	}
+}
+
+// NewBig returns a new [BigFunc] that computes with prec bits of precision.
+//
+// It is slow, but it is not limited by the float64 precision.
+// It can be used to verify the result of a [Func], or to compute points that are below the float64 resolution.
+func NewBig(maxIter int, prec uint) BigFunc {
+	return func(re, im *big.Float) Result {
+		c := newBigComplex(re, im, prec)
+		z := newBigComplex(re, im, prec)
+		p := newBigPow2(prec)
+		for iter := range maxIter {
+			absSquare := p.absSquare(z)
+			if absSquare > bailoutSquare {
+				abs := math.Sqrt(absSquare)
+				return Result{
+					Bounded: false,
+					Iter:    iter,
+					Abs:     abs,
+				}
+			}
+			p.step(z, c)
+		}
+		return Result{
+			Bounded: true,
+			Iter:    maxIter,
+		}
+	}
+}
+
+// bigComplex is an arbitrary-precision complex number.
+type bigComplex struct {
+	real *big.Float
+	imag *big.Float
+}
+
+func newBigComplex(re, im *big.Float, prec uint) bigComplex {
+	return bigComplex{
+		real: new(big.Float).SetPrec(prec).Set(re),
+		imag: new(big.Float).SetPrec(prec).Set(im),
+	}
+}
+
+// bigPow2 computes z = z*z + c.
+//
+// It keeps temporary values, in order to avoid allocations.
+type bigPow2 struct {
+	tmp1 *big.Float
+	tmp2 *big.Float
+}
+
+func newBigPow2(prec uint) *bigPow2 {
+	return &bigPow2{
+		tmp1: new(big.Float).SetPrec(prec),
+		tmp2: new(big.Float).SetPrec(prec),
+	}
+}
+
+func (p *bigPow2) step(z, c bigComplex) {
+	// real = real*real - imag*imag + c.real
+	p.tmp1.Mul(z.real, z.real)
+	p.tmp2.Mul(z.imag, z.imag)
+	p.tmp1.Sub(p.tmp1, p.tmp2)
+	// imag = 2*real*imag + c.imag
+	z.imag.Mul(z.imag, z.real)
+	z.imag.Add(z.imag, z.imag)
+	z.imag.Add(z.imag, c.imag)
+	z.real.Add(p.tmp1, c.real)
+}
+
+func (p *bigPow2) absSquare(z bigComplex) float64 {
+	p.tmp1.Mul(z.real, z.real)
+	p.tmp2.Mul(z.imag, z.imag)
+	p.tmp1.Add(p.tmp1, p.tmp2)
+	f, _ := p.tmp1.Float64()
+	return f
+}
+
+// complex128 returns the nearest complex128 value.
+func (z bigComplex) complex128() complex128 {
+	re, _ := z.real.Float64()
+	im, _ := z.imag.Float64()
+	return complex(re, im)
+}
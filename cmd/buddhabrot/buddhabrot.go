@@ -0,0 +1,35 @@
+// Package buddhabrot provides an example of rendering a Nebulabrot image.
+package main
+
+import (
+	"image"
+	"log"
+	"math"
+
+	mandelbrot_cmd "github.com/pierrre/mandelbrot/cmd"
+	mandelbrot_image "github.com/pierrre/mandelbrot/image"
+	mandelbrot_image_buddhabrot "github.com/pierrre/mandelbrot/image/buddhabrot"
+)
+
+func main() {
+	size := image.Pt(1024, 1024)
+	rotate := -math.Pi / 2
+	scale := 1.3
+	translate := complex(-0.4, 0)
+
+	im := image.NewRGBA(image.Rect(0, 0, size.X, size.Y))
+	scale *= mandelbrot_image.Scale(size)
+	tsf := mandelbrot_image.BaseTransformation(im, rotate, scale, translate)
+	h := mandelbrot_image_buddhabrot.Render(im.Bounds(), tsf, mandelbrot_image_buddhabrot.Options{
+		Samples:    1 << 24,
+		Seed:       1,
+		Stratified: true,
+		MaxIter:    [3]int{5000, 500, 50},
+	})
+	h.Draw(im, mandelbrot_image_buddhabrot.GammaToneMapping(2.2))
+
+	err := mandelbrot_cmd.Save(im, "buddhabrot.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
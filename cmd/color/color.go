@@ -26,12 +26,12 @@ func main() {
 	scale *= mandelbrot_image.Scale(smoothSize)
 	tsf := mandelbrot_image.BaseTransformation(im, rotate, scale, translate)
 	maxIter := mandelbrot_image.MaxIter(scale)
-	f := mandelbrot.New(maxIter)
+	f := mandelbrot.NewBatch(maxIter)
 	clr := mandelbrot_image.BoundColorizer(
 		mandelbrot_image.ColorColorizer(color.Black),
 		mandelbrot_image_colorizer_rainbow.Colorizer(16, 0),
 	)
-	mandelbrot_image.RenderParallel(im, tsf, f, clr)
+	mandelbrot_image.RenderParallelBatch(im, tsf, f, clr)
 
 	if smooth > 0 {
 		g := gift.New(gift.Resize(size.X, size.Y, gift.LanczosResampling))
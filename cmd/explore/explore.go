@@ -7,6 +7,7 @@ import (
 	"image/color"
 	"log"
 	"math"
+	"math/big"
 	"math/rand"
 
 	"github.com/pierrre/mandelbrot"
@@ -19,7 +20,7 @@ func main() {
 	size := image.Pt(512, 512)
 	rotate := 0.0
 	baseScale := 1.0
-	translate := complex(0, 0)
+	translateReal, translateImag := new(big.Float), new(big.Float)
 	steps, stepScale := 50, 2.0 // 50,2.0 | 85,1.5 | 155,1.25
 
 	boundedColor := color.Black
@@ -32,11 +33,15 @@ func main() {
 
 	for step := range steps {
 		scale := baseScale * mandelbrot_image.Scale(size) * math.Pow(stepScale, float64(step))
-		tsf := mandelbrot_image.BaseTransformation(im, rotate, scale, translate)
+		// The transformation returns the offset from the translation, which is handled by the perturbation.
+		tsf := mandelbrot_image.BaseTransformation(im, rotate, scale, 0)
 		maxIter := mandelbrot_image.MaxIter(scale)
-		f := mandelbrot.New(maxIter)
+		prec := mandelbrot_image.Precision(scale)
+		translateReal.SetPrec(prec)
+		translateImag.SetPrec(prec)
+		f := mandelbrot.NewPerturbation(maxIter, prec, translateReal, translateImag)
 
-		log.Printf("step=%d translate=%g scale=%v maxIter=%d", step, translate, scale, maxIter)
+		log.Printf("step=%d translate=(%s,%s) scale=%v maxIter=%d", step, translateReal.Text('g', -1), translateImag.Text('g', -1), scale, maxIter)
 
 		mandelbrot_image.RenderParallel(im, tsf, f, clr)
 
@@ -47,7 +52,9 @@ func main() {
 		}
 
 		p := findBorderBoundedPoint(im, boundedColor)
-		translate = tsf(complex(float64(p.X), float64(p.Y)))
+		offset := tsf(complex(float64(p.X), float64(p.Y)))
+		translateReal.Add(translateReal, big.NewFloat(real(offset)))
+		translateImag.Add(translateImag, big.NewFloat(imag(offset)))
 	}
 }
 
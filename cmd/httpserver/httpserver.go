@@ -7,8 +7,11 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"math"
+	"math/big"
 	"net/http"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/disintegration/gift"
@@ -21,6 +24,7 @@ import (
 	imageserver_image_gamma "github.com/pierrre/imageserver/image/gamma"
 	_ "github.com/pierrre/imageserver/image/png"
 	"github.com/pierrre/mandelbrot"
+	"github.com/pierrre/mandelbrot/formula"
 	mandelbrot_image "github.com/pierrre/mandelbrot/image"
 	mandelbrot_image_colorizer_rainbow "github.com/pierrre/mandelbrot/image/colorizer/rainbow"
 )
@@ -31,6 +35,7 @@ var (
 	flagCache               = int64(64 * (1 << 20))
 	flagQuality             = uint(0)
 	flagMaxIter             = int(1000)
+	flagFractal             = "mandelbrot"
 )
 
 func main() {
@@ -44,6 +49,7 @@ func parseFlags() {
 	flag.Int64Var(&flagCache, "cache", flagCache, "Cache")
 	flag.UintVar(&flagQuality, "quality", flagQuality, "Quality")
 	flag.IntVar(&flagMaxIter, "max-iter", flagMaxIter, "Max iter")
+	flag.StringVar(&flagFractal, "fractal", flagFractal, "Fractal (mandelbrot, newton)")
 	flag.Parse()
 }
 
@@ -79,35 +85,75 @@ func rootHTTPHandler(w http.ResponseWriter, req *http.Request) {
 		#map {
 			height: 100%;
 		}
+		#formula, #k {
+			position: absolute;
+			right: 8px;
+			width: 240px;
+		}
+		#formula {
+			top: 8px;
+		}
+		#k {
+			top: 36px;
+		}
 	</style>
 	<link rel="stylesheet" type="text/css" href="//cdnjs.cloudflare.com/ajax/libs/ol3/3.20.1/ol.css">
 	<script type="text/javascript" src="//cdnjs.cloudflare.com/ajax/libs/ol3/3.20.1/ol.js"></script>
 </head>
 <body>
 	<div id="map"></div>
+	<input id="formula" type="text" placeholder="Formula, e.g. z^3 - 0.5*z + c">
+	<input id="k" type="text" placeholder="Constant k, e.g. -0.8+0.156i">
 
 	<script type="text/javascript">
+		var maxZoom = ` + strconv.Itoa(getMaxTileZ(false)) + `;
+		var formulaMaxZoom = ` + strconv.Itoa(getMaxTileZ(true)) + `;
 		var extent = [-2, -2, 2, 2];
 		var projection = new ol.proj.Projection({
 			extent: extent
 		});
+		var source = new ol.source.XYZ({
+			url: '/i?x={x}&y={y}&z={z}',
+			projection: projection
+		});
 		var map = new ol.Map({
 			target: 'map',
 			layers: [
 				new ol.layer.Tile({
-					source: new ol.source.XYZ({
-						url: '/i?x={x}&y={y}&z={z}',
-						projection: projection
-					})
+					source: source
 				})
 			],
 			view: new ol.View({
 				projection: projection,
 				center: ol.extent.getCenter(extent),
 				zoom: 0,
-				maxZoom: 42
+				maxZoom: maxZoom
 			})
 		});
+		var setMaxZoom = function(max) {
+			var view = map.getView();
+			map.setView(new ol.View({
+				projection: projection,
+				center: view.getCenter(),
+				zoom: Math.min(view.getZoom(), max),
+				maxZoom: max
+			}));
+		};
+		var updateURL = function() {
+			var url = '/i?x={x}&y={y}&z={z}';
+			var formula = document.getElementById('formula').value;
+			setMaxZoom(formula ? formulaMaxZoom : maxZoom);
+			if (formula) {
+				url += '&formula=' + encodeURIComponent(formula);
+				var k = document.getElementById('k').value;
+				if (k) {
+					url += '&k=' + encodeURIComponent(k);
+				}
+			}
+			source.setUrl(url);
+		};
+		document.getElementById('formula').addEventListener('change', updateURL);
+		document.getElementById('k').addEventListener('change', updateURL);
 	</script>
 </body>
 </html>`))
@@ -115,8 +161,13 @@ func rootHTTPHandler(w http.ResponseWriter, req *http.Request) {
 
 const (
 	tileSize = 256
-	maxTileZ = 42
-	radius   = 2
+	// maxTileZ is the maximum zoom level: the tile coordinates must fit in int64.
+	maxTileZ = 62
+	// maxFloatTileZ is the maximum zoom level of the fractals computed with float64, which lose their precision beyond it.
+	maxFloatTileZ = 42
+	// perturbationTileZ is the zoom level from which the Mandelbrot set is computed with perturbation theory, because the float64 precision is not enough.
+	perturbationTileZ = 32
+	radius            = 2
 )
 
 func newImageHTTPHandler() http.Handler {
@@ -145,11 +196,13 @@ func (prs *mandelbrotHTTPParser) Parse(req *http.Request, params imageserver.Par
 			return err //nolint:wrapcheck // TODO implement error handling.
 		}
 	}
+	imageserver_http.ParseQueryString("formula", req, params)
+	imageserver_http.ParseQueryString("k", req, params)
 	return nil
 }
 
 func (prs *mandelbrotHTTPParser) Resolve(param string) string {
-	if param == "x" || param == "y" || param == "z" {
+	if param == "x" || param == "y" || param == "z" || param == "formula" || param == "k" {
 		return param
 	}
 	return ""
@@ -178,19 +231,15 @@ func newImageCacheServer(srv imageserver.Server) imageserver.Server {
 
 func newImageProvider() imageserver_image.Provider {
 	tileRenderSize := tileSize << flagQuality
-	clr := mandelbrot_image.BoundColorizer(
-		mandelbrot_image.ColorColorizer(color.Black),
-		mandelbrot_image_colorizer_rainbow.Colorizer(16, 0),
-	)
+	f, clr := newFractal()
 	var prv imageserver_image.Provider
 	prv = imageserver_image.ProviderFunc(func(params imageserver.Params) (image.Image, error) {
-		tsf, err := getTransformation(params, tileRenderSize)
+		tsf, tileF, tileClr, err := getTileFractal(params, tileRenderSize, f, clr)
 		if err != nil {
 			return nil, err
 		}
-		f := mandelbrot.New(flagMaxIter)
 		im := image.NewNRGBA(image.Rect(0, 0, tileRenderSize, tileRenderSize))
-		mandelbrot_image.Render(im, tsf, f, clr)
+		mandelbrot_image.Render(im, tsf, tileF, tileClr)
 		return im, nil
 	})
 	if tileRenderSize != tileSize {
@@ -210,23 +259,142 @@ func newImageProvider() imageserver_image.Provider {
 	return prv
 }
 
-func getTransformation(params imageserver.Params, tileRenderSize int) (mandelbrot_image.Transformation, error) {
+func newFractal() (mandelbrot.Func, mandelbrot_image.Colorizer) {
+	switch flagFractal {
+	case "newton":
+		f := mandelbrot.NewNewtonCoeffs(flagMaxIter, []complex128{-1, 0, 0, 1})
+		clr := mandelbrot_image.RootColorizer([]color.Color{
+			color.RGBA{R: 255, A: 255},
+			color.RGBA{G: 255, A: 255},
+			color.RGBA{B: 255, A: 255},
+		}, 0.05)
+		return f, clr
+	default:
+		return mandelbrot.New(flagMaxIter), newColorizer()
+	}
+}
+
+func newColorizer() mandelbrot_image.Colorizer {
+	return mandelbrot_image.BoundColorizer(
+		mandelbrot_image.ColorColorizer(color.Black),
+		mandelbrot_image_colorizer_rainbow.Colorizer(16, 0),
+	)
+}
+
+// isPerturbation returns true if the deep zoom tiles are computed with perturbation theory.
+//
+// It is only supported by the default Mandelbrot set.
+func isPerturbation(isFormula bool) bool {
+	return flagFractal == "mandelbrot" && !isFormula
+}
+
+// getMaxTileZ returns the maximum zoom level of the fractal.
+func getMaxTileZ(isFormula bool) int {
+	if isPerturbation(isFormula) {
+		return maxTileZ
+	}
+	return maxFloatTileZ
+}
+
+// getTileFractal returns the transformation and the fractal for the tile.
+func getTileFractal(params imageserver.Params, tileRenderSize int, f mandelbrot.Func, clr mandelbrot_image.Colorizer) (mandelbrot_image.Transformation, mandelbrot.Func, mandelbrot_image.Colorizer, error) {
 	tileX, tileY, tileZ, err := getTileXYZParam(params)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
+	}
+	isFormula := params.Has("formula")
+	if maxZ := getMaxTileZ(isFormula); tileZ > int64(maxZ) {
+		return nil, nil, nil, &imageserver.ParamError{Param: "z", Message: fmt.Sprintf("must be between 0 and %d for this fractal", maxZ)}
+	}
+	if isPerturbation(isFormula) && tileZ >= perturbationTileZ {
+		tsf, tileF := getPerturbationTile(tileX, tileY, tileZ, tileRenderSize)
+		return tsf, tileF, clr, nil
+	}
+	f, clr, err = getFormulaFractal(params, f, clr)
+	if err != nil {
+		return nil, nil, nil, err
 	}
-	tileCount := int64(1) << tileZ
-	halfPix := tileCount * int64(tileRenderSize) / 2
+	return getTransformation(tileX, tileY, tileZ, tileRenderSize), f, clr, nil
+}
+
+// getFormulaFractal returns the fractal for the "formula" param, or the default fractal if it is not defined.
+//
+// The optional "k" param is the constant k of the formula, e.g. "-0.8+0.156i".
+func getFormulaFractal(params imageserver.Params, f mandelbrot.Func, clr mandelbrot_image.Colorizer) (mandelbrot.Func, mandelbrot_image.Colorizer, error) {
+	if !params.Has("formula") {
+		return f, clr, nil
+	}
+	s, err := params.GetString("formula")
+	if err != nil {
+		return nil, nil, err //nolint:wrapcheck // TODO implement error handling.
+	}
+	k, err := getFormulaKParam(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err = formula.NewFunc(s, flagMaxIter, k)
+	if err != nil {
+		return nil, nil, &imageserver.ParamError{Param: "formula", Message: err.Error()}
+	}
+	return f, newColorizer(), nil
+}
+
+func getFormulaKParam(params imageserver.Params) (complex128, error) {
+	if !params.Has("k") {
+		return 0, nil
+	}
+	s, err := params.GetString("k")
+	if err != nil {
+		return 0, err //nolint:wrapcheck // TODO implement error handling.
+	}
+	k, err := strconv.ParseComplex(s, 128)
+	if err != nil {
+		return 0, &imageserver.ParamError{Param: "k", Message: "invalid complex number"}
+	}
+	return k, nil
+}
+
+func getTransformation(tileX, tileY, tileZ int64, tileRenderSize int) mandelbrot_image.Transformation {
+	// It uses float64, because the int64 values overflow for the highest zoom levels.
+	halfPix := math.Ldexp(float64(tileRenderSize), int(tileZ)-1)
 	tilePixOff := complex(
-		float64(tileX*int64(tileRenderSize)-halfPix),
-		float64(tileY*int64(tileRenderSize)-halfPix),
+		float64(tileX)*float64(tileRenderSize)-halfPix,
+		float64(tileY)*float64(tileRenderSize)-halfPix,
 	)
-	invScale := float64(radius) / float64(halfPix)
+	invScale := float64(radius) / halfPix
 	return func(c complex128) complex128 {
 		c += tilePixOff
 		c = complex(real(c)*invScale, -imag(c)*invScale)
 		return c
-	}, nil
+	}
+}
+
+// getPerturbationTile returns the transformation and the fractal for a deep zoom tile.
+//
+// The center of the tile is computed with arbitrary precision, and the transformation returns the offset from it.
+func getPerturbationTile(tileX, tileY, tileZ int64, tileRenderSize int) (mandelbrot_image.Transformation, mandelbrot.Func) {
+	scale := math.Ldexp(float64(tileRenderSize), int(tileZ)) / (2 * radius)
+	prec := mandelbrot_image.Precision(scale)
+	centerReal := getTileCenter(tileX, tileZ, prec)
+	// The y axis of the tiles is inverted.
+	centerImag := getTileCenter(tileY, tileZ, prec)
+	centerImag.Neg(centerImag)
+	f := mandelbrot.NewPerturbation(flagMaxIter, prec, centerReal, centerImag)
+	half := complex(float64(tileRenderSize)/2, float64(tileRenderSize)/2)
+	invScale := 1 / scale
+	return func(c complex128) complex128 {
+		c -= half
+		c = complex(real(c)*invScale, -imag(c)*invScale)
+		return c
+	}, f
+}
+
+// getTileCenter returns the coordinate of the center of the tile: radius*((2*tile+1)/2^tileZ - 1).
+func getTileCenter(tile, tileZ int64, prec uint) *big.Float {
+	v := new(big.Float).SetPrec(prec).SetInt64(2*tile + 1)
+	v.SetMantExp(v, -int(tileZ))
+	v.Sub(v, big.NewFloat(1))
+	return v.Mul(v, big.NewFloat(radius))
 }
 
 func getTileXYZParam(params imageserver.Params) (tileX, tileY, tileZ int64, err error) {
@@ -0,0 +1,36 @@
+// Package mandelbulb provides an example of rendering a Mandelbulb image.
+package main
+
+import (
+	"image"
+	"image/color"
+	"log"
+
+	mandelbrot_cmd "github.com/pierrre/mandelbrot/cmd"
+	mandelbrot_image_raymarch "github.com/pierrre/mandelbrot/image/raymarch"
+)
+
+func main() {
+	size := image.Pt(1024, 768)
+	power := 8.0
+	maxIter := 12
+
+	im := image.NewRGBA(image.Rect(0, 0, size.X, size.Y))
+	cam := mandelbrot_image_raymarch.Camera{
+		Position: mandelbrot_image_raymarch.Vec3{X: 0.3, Y: 1.4, Z: -2.2},
+	}
+	de := mandelbrot_image_raymarch.NewMandelbulb(maxIter, power)
+	mandelbrot_image_raymarch.RenderParallel(im, cam, de, mandelbrot_image_raymarch.Options{
+		Epsilon:          1e-4,
+		Color:            color.RGBA{R: 230, G: 180, B: 120, A: 255},
+		Ambient:          0.3,
+		Specular:         0.5,
+		Shadow:           8,
+		AmbientOcclusion: true,
+	})
+
+	err := mandelbrot_cmd.Save(im, "mandelbulb.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+}
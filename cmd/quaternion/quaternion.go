@@ -0,0 +1,45 @@
+// Package quaternion provides an example of animating the 3D slices of a quaternion Julia set.
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"log"
+
+	mandelbrot_cmd "github.com/pierrre/mandelbrot/cmd"
+	mandelbrot_image_raymarch "github.com/pierrre/mandelbrot/image/raymarch"
+)
+
+func main() {
+	size := image.Pt(512, 384)
+	maxIter := 12
+	c := mandelbrot_image_raymarch.Quaternion{A: -0.2, B: 0.6, C: 0.2, D: 0.2}
+	steps, minSlice, maxSlice := 100, -1.0, 1.0
+
+	im := image.NewRGBA(image.Rect(0, 0, size.X, size.Y))
+	cam := mandelbrot_image_raymarch.Camera{
+		Position: mandelbrot_image_raymarch.Vec3{X: 0.5, Y: 1.5, Z: -2.6},
+	}
+	opts := mandelbrot_image_raymarch.Options{
+		Color:            color.RGBA{R: 120, G: 180, B: 230, A: 255},
+		Ambient:          0.3,
+		Specular:         0.5,
+		Shadow:           8,
+		AmbientOcclusion: true,
+	}
+
+	for step := range steps {
+		slice := minSlice + (maxSlice-minSlice)*float64(step)/float64(steps-1)
+		log.Printf("step=%d slice=%v", step, slice)
+
+		de := mandelbrot_image_raymarch.NewQuaternionJulia(maxIter, c, slice)
+		mandelbrot_image_raymarch.RenderParallel(im, cam, de, opts)
+
+		file := fmt.Sprintf("quaternion_%04d.png", step)
+		err := mandelbrot_cmd.Save(im, file)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+}
@@ -21,9 +21,9 @@ func main() {
 	scale *= mandelbrot_image.Scale(size)
 	tsf := mandelbrot_image.BaseTransformation(im, rotate, scale, translate)
 	maxIter := mandelbrot_image.MaxIter(scale)
-	f := mandelbrot.New(maxIter)
+	f := mandelbrot.NewBatch(maxIter)
 	clr := mandelbrot_image.BWColorizer(false)
-	mandelbrot_image.RenderParallel(im, tsf, f, clr)
+	mandelbrot_image.RenderParallelBatch(im, tsf, f, clr)
 
 	err := mandelbrot_cmd.Save(im, "simple.png")
 	if err != nil {
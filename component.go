@@ -0,0 +1,87 @@
+package mandelbrot
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+const (
+	mainComponentBins       = 512
+	mainComponentBinSamples = 16
+	// mainComponentMargin makes the test conservative, because the boundary is sampled.
+	mainComponentMargin = 0.999
+)
+
+// mainComponent tests if a point is inside the main hyperbolic component of a multibrot set z = z^d + c.
+//
+// The boundary of the component is known analytically: for a multiplier |m| = 1, the fixed point is z = (m/d)^(1/(d-1)) and c = z - z^d = z*(1 - m/d).
+// It is sampled into a table of the minimum radius per angle, which is star-shaped around 0.
+// The component has a rotational symmetry of order d-1.
+type mainComponent struct {
+	innerSquare float64
+	radii       []float64
+}
+
+func newMainComponent(d int) *mainComponent {
+	df := float64(d)
+	// radius of the inscribed disc: (d-1) * d^(-d/(d-1))
+	inner := (df - 1) * math.Pow(df, -df/(df-1))
+	if d-1 >= mainComponentBins {
+		// Each bin contains at least a full sector, so its minimum radius is the inscribed disc.
+		return &mainComponent{
+			innerSquare: inner * inner,
+		}
+	}
+	radii := make([]float64, mainComponentBins)
+	for i := range radii {
+		radii[i] = math.Inf(1)
+	}
+	// |z| = d^(-1/(d-1))
+	absZ := math.Pow(df, -1/(df-1))
+	// A full turn of the multiplier gives a sector of the boundary, which is rotated d-1 times.
+	// The number of samples is divided by the number of sectors, so the cost doesn't depend on d.
+	samples := max(mainComponentBins*mainComponentBinSamples/(d-1), mainComponentBinSamples)
+	for i := range samples {
+		angle := 2 * math.Pi * float64(i) / float64(samples)
+		m := cmplx.Rect(1, angle)
+		z := cmplx.Rect(absZ, angle/(df-1))
+		c := z * (1 - m/complex(df, 0))
+		r := cmplx.Abs(c)
+		for k := range d - 1 {
+			angle := cmplx.Phase(c) + 2*math.Pi*float64(k)/(df-1)
+			bin := mainComponentBin(angle)
+			radii[bin] = min(radii[bin], r)
+		}
+	}
+	// The boundary between 2 samples can be closer than the samples, so the neighbor bins are also used.
+	conservative := make([]float64, mainComponentBins)
+	for i := range conservative {
+		prev := radii[(i+mainComponentBins-1)%mainComponentBins]
+		next := radii[(i+1)%mainComponentBins]
+		conservative[i] = max(min(prev, radii[i], next)*mainComponentMargin, inner)
+	}
+	return &mainComponent{
+		innerSquare: inner * inner,
+		radii:       conservative,
+	}
+}
+
+func mainComponentBin(angle float64) int {
+	angle = math.Mod(angle, 2*math.Pi)
+	if angle < 0 {
+		angle += 2 * math.Pi
+	}
+	return min(int(angle/(2*math.Pi)*mainComponentBins), mainComponentBins-1)
+}
+
+func (mc *mainComponent) contains(c complex128) bool {
+	absSquare := real(c)*real(c) + imag(c)*imag(c)
+	if absSquare < mc.innerSquare {
+		return true
+	}
+	if mc.radii == nil {
+		return false
+	}
+	r := mc.radii[mainComponentBin(math.Atan2(imag(c), real(c)))]
+	return absSquare < r*r
+}
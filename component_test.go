@@ -0,0 +1,40 @@
+package mandelbrot
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand/v2"
+	"strconv"
+	"testing"
+
+	"github.com/pierrre/assert"
+)
+
+// TestMainComponentContains checks that the points contained in the main component don't escape.
+//
+// The points are sampled near the boundary of the component, where the test is approximate.
+// The cycle detection stops the bounded orbits early, with a tolerance much lower than the distance of an escaping orbit between 2 iterations.
+func TestMainComponentContains(t *testing.T) {
+	const (
+		samples     = 20000
+		maxIter     = 20000
+		periodicity = 1e-14
+	)
+	for d := 3; d <= 33; d++ {
+		t.Run(strconv.Itoa(d), func(t *testing.T) {
+			mc := newMainComponent(d)
+			pow := getPow(float64(d))
+			rnd := rand.New(rand.NewPCG(uint64(d), 0))
+			for range samples {
+				// The boundary of the main component is between the inscribed disc and the unit disc.
+				r := math.Sqrt(mc.innerSquare)
+				c := cmplx.Rect(r+(1-r)*rnd.Float64(), 2*math.Pi*rnd.Float64())
+				if !mc.contains(c) {
+					continue
+				}
+				res := iterPow(c, c, maxIter, pow, periodicity)
+				assert.True(t, res.Bounded, assert.MessageWrapf("point %v", c))
+			}
+		})
+	}
+}
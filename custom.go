@@ -0,0 +1,40 @@
+package mandelbrot
+
+import (
+	"math/cmplx"
+)
+
+// NewCustom returns a new [Func] for a custom formula.
+//
+// step returns the next value of z, and escape returns true if z escaped.
+// If escape is nil, the default escape test |z| > 2 is used.
+// The orbit starts with z = c.
+func NewCustom(maxIter int, step func(z, c complex128) complex128, escape func(z complex128) bool) Func {
+	if escape == nil {
+		escape = defaultEscape
+	}
+	return func(c complex128) Result {
+		return iterCustom(c, c, maxIter, step, escape)
+	}
+}
+
+func defaultEscape(z complex128) bool {
+	return real(z)*real(z)+imag(z)*imag(z) > bailoutSquare
+}
+
+func iterCustom(z, c complex128, maxIter int, step func(z, c complex128) complex128, escape func(z complex128) bool) Result {
+	for iter := range maxIter {
+		if escape(z) {
+			return Result{
+				Bounded: false,
+				Iter:    iter,
+				Abs:     cmplx.Abs(z),
+			}
+		}
+		z = step(z, c)
+	}
+	return Result{
+		Bounded: true,
+		Iter:    maxIter,
+	}
+}
@@ -0,0 +1,77 @@
+// Package formula provides a parser and a compiler for formula expressions.
+//
+// An expression such as "z^3 - 0.5*z + c" or "sin(z)*c" is compiled to a tree of closures, that can be used as a [mandelbrot.Func].
+//
+// Syntax:
+//   - numbers: "2", "0.5", "1e-3", and imaginary numbers "2i", "i"
+//   - variables: "z" (current value), "c" (parameter), "k" (constant), "pixel" (alias of c, the point)
+//   - operators: "+", "-", "*", "/", "^" (power, right associative)
+//   - functions: sin, cos, tan, sinh, cosh, tanh, exp, log, sqrt, abs, conj, re, im
+package formula
+
+import (
+	"fmt"
+
+	"github.com/pierrre/mandelbrot"
+)
+
+// Vars contains the values of the variables.
+type Vars struct {
+	Z complex128
+	C complex128
+	K complex128
+}
+
+// Expr is a compiled expression.
+type Expr func(vars Vars) complex128
+
+// ParseError is an error that occurred while parsing an expression.
+type ParseError struct {
+	// Pos is the position (in bytes) in the expression.
+	Pos int
+	Msg string
+}
+
+func (err *ParseError) Error() string {
+	return fmt.Sprintf("position %d: %s", err.Pos, err.Msg)
+}
+
+// Parse parses and compiles an expression.
+//
+// The returned error is a [*ParseError].
+func Parse(s string) (Expr, error) {
+	p := &parser{
+		lexer: lexer{s: s},
+	}
+	err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	n, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.tok.kind != tokenEOF {
+		return nil, p.errorf("unexpected %q", p.tok.text)
+	}
+	return n.expr(), nil
+}
+
+// NewFunc returns a new [mandelbrot.Func] for the formula of the next value of z.
+//
+// The orbit starts with z = c, and k is a constant.
+// For example, "z^2 + c" is the Mandelbrot set, and "z^2 + k" is the Julia set for k.
+func NewFunc(s string, maxIter int, k complex128) (mandelbrot.Func, error) {
+	e, err := Parse(s)
+	if err != nil {
+		return nil, err
+	}
+	return mandelbrot.NewCustom(maxIter, func(z, c complex128) complex128 {
+		// The variables are passed by value, so they are not allocated.
+		return e(Vars{
+			Z: z,
+			C: c,
+			K: k,
+		})
+	}, nil), nil
+}
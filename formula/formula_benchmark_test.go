@@ -0,0 +1,25 @@
+package formula
+
+import (
+	"testing"
+)
+
+func BenchmarkParse(b *testing.B) {
+	for b.Loop() {
+		_, err := Parse("z^3 - 0.5*z + c")
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkFunc(b *testing.B) {
+	f, err := NewFunc("z^3 - 0.5*z + c", 1000, 0)
+	if err != nil {
+		b.Fatal(err)
+	}
+	c := complex(0.1, 0.1)
+	for b.Loop() {
+		f(c)
+	}
+}
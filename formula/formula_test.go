@@ -0,0 +1,117 @@
+package formula
+
+import (
+	"math"
+	"math/cmplx"
+	"strings"
+	"testing"
+
+	"github.com/pierrre/assert"
+)
+
+func TestParse(t *testing.T) {
+	vars := Vars{
+		Z: 2,
+		C: complex(0.5, 1),
+		K: complex(-1, 0.25),
+	}
+	for _, tc := range []struct {
+		expr     string
+		expected complex128
+	}{
+		{expr: "z", expected: 2},
+		{expr: "c", expected: complex(0.5, 1)},
+		{expr: "k", expected: complex(-1, 0.25)},
+		{expr: "pixel", expected: complex(0.5, 1)},
+		{expr: "1.5e1", expected: 15},
+		{expr: ".5", expected: 0.5},
+		{expr: "2i", expected: 2i},
+		{expr: "i", expected: 1i},
+		{expr: "z^2 + c", expected: complex(4.5, 1)},
+		{expr: "z^3 - 0.5*z + c", expected: complex(7.5, 1)},
+		{expr: "1 + 2*3", expected: 7},
+		{expr: "(1 + 2)*3", expected: 9},
+		{expr: "8/2/2", expected: 2},
+		{expr: "8 - 2 - 2", expected: 4},
+		{expr: "-z^2", expected: -4},
+		{expr: "--z", expected: 2},
+		{expr: "+z", expected: 2},
+		{expr: "2^-1", expected: 0.5},
+		{expr: "z^-2", expected: 0.25},
+		{expr: "z^2^0.5", expected: cmplx.Pow(2, math.Sqrt2)},
+		{expr: "2^3^2", expected: 512},
+		{expr: "-2^2", expected: -4},
+		{expr: "(-2)^2", expected: 4},
+		{expr: "2*-z", expected: -4},
+		{expr: "z^c", expected: cmplx.Pow(2, complex(0.5, 1))},
+		{expr: "sin(z)*c", expected: cmplx.Sin(2) * complex(0.5, 1)},
+		{expr: "exp(log(z))", expected: 2},
+		{expr: "sqrt(z*z)", expected: 2},
+		{expr: "abs(3 + 4i)", expected: 5},
+		{expr: "conj(c)", expected: complex(0.5, -1)},
+		{expr: "re(c) + im(c)", expected: 1.5},
+	} {
+		t.Run(tc.expr, func(t *testing.T) {
+			e, err := Parse(tc.expr)
+			assert.NoError(t, err)
+			assert.Less(t, cmplx.Abs(e(vars)-tc.expected), 1e-12)
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	for _, tc := range []struct {
+		expr string
+		pos  int
+	}{
+		{expr: "", pos: 0},
+		{expr: "z +", pos: 3},
+		{expr: "z $ c", pos: 2},
+		{expr: "z c", pos: 2},
+		{expr: "(z + c", pos: 6},
+		{expr: "z + c)", pos: 5},
+		{expr: "foo(z)", pos: 0},
+		{expr: "z + bar", pos: 4},
+		{expr: "sin z", pos: 4},
+		{expr: "1..2 + z", pos: 0},
+		{expr: "z^", pos: 2},
+		{expr: strings.Repeat("(", 2000) + "z" + strings.Repeat(")", 2000), pos: maxDepth},
+		{expr: strings.Repeat("-", 2000) + "z", pos: maxDepth},
+	} {
+		name := tc.expr
+		if len(name) > 20 {
+			name = name[:20]
+		}
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(tc.expr)
+			perr, ok := assert.ErrorAsType[*ParseError](t, err)
+			if ok {
+				assert.Equal(t, perr.Pos, tc.pos)
+			}
+		})
+	}
+}
+
+func TestNewFunc(t *testing.T) {
+	f, err := NewFunc("z^2 + c", 100, 0)
+	assert.NoError(t, err)
+	res := f(0)
+	assert.True(t, res.Bounded)
+	res = f(1)
+	assert.False(t, res.Bounded)
+	assert.Equal(t, res.Iter, 2)
+}
+
+func TestNewFuncJulia(t *testing.T) {
+	f, err := NewFunc("z^2 + k", 100, -1)
+	assert.NoError(t, err)
+	res := f(0)
+	assert.True(t, res.Bounded)
+	res = f(2)
+	assert.False(t, res.Bounded)
+}
+
+func TestNewFuncError(t *testing.T) {
+	_, err := NewFunc("z^2 +", 100, 0)
+	assert.Error(t, err)
+}
@@ -0,0 +1,87 @@
+package formula
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+type lexer struct {
+	s   string
+	pos int
+}
+
+const operators = "+-*/^(),"
+
+func (l *lexer) next() (token, error) {
+	for l.pos < len(l.s) && isSpace(l.s[l.pos]) {
+		l.pos++
+	}
+	start := l.pos
+	if l.pos >= len(l.s) {
+		return token{kind: tokenEOF, pos: start}, nil
+	}
+	ch := l.s[l.pos]
+	switch {
+	case isDigit(ch) || ch == '.':
+		l.scanNumber()
+		return token{kind: tokenNumber, text: l.s[start:l.pos], pos: start}, nil
+	case isLetter(ch):
+		for l.pos < len(l.s) && (isLetter(l.s[l.pos]) || isDigit(l.s[l.pos])) {
+			l.pos++
+		}
+		return token{kind: tokenIdent, text: l.s[start:l.pos], pos: start}, nil
+	case strings.IndexByte(operators, ch) >= 0:
+		l.pos++
+		return token{kind: tokenOperator, text: l.s[start:l.pos], pos: start}, nil
+	}
+	return token{}, &ParseError{Pos: start, Msg: fmt.Sprintf("unexpected character %q", ch)}
+}
+
+// scanNumber scans a decimal number, with an optional exponent and an optional "i" suffix.
+func (l *lexer) scanNumber() {
+	for l.pos < len(l.s) && (isDigit(l.s[l.pos]) || l.s[l.pos] == '.') {
+		l.pos++
+	}
+	if l.pos < len(l.s) && (l.s[l.pos] == 'e' || l.s[l.pos] == 'E') {
+		p := l.pos + 1
+		if p < len(l.s) && (l.s[p] == '+' || l.s[p] == '-') {
+			p++
+		}
+		if p < len(l.s) && isDigit(l.s[p]) {
+			for p < len(l.s) && isDigit(l.s[p]) {
+				p++
+			}
+			l.pos = p
+		}
+	}
+	if l.pos < len(l.s) && l.s[l.pos] == 'i' && (l.pos+1 >= len(l.s) || !isLetter(l.s[l.pos+1]) && !isDigit(l.s[l.pos+1])) {
+		l.pos++
+	}
+}
+
+func isSpace(ch byte) bool {
+	return ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r'
+}
+
+func isDigit(ch byte) bool {
+	return '0' <= ch && ch <= '9'
+}
+
+func isLetter(ch byte) bool {
+	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_'
+}
@@ -0,0 +1,367 @@
+package formula
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"strconv"
+	"strings"
+)
+
+// maxDepth is the maximum nesting depth of an expression.
+//
+// It prevents a stack overflow with a deeply nested expression.
+const maxDepth = 1000
+
+type parser struct {
+	lexer lexer
+	tok   token
+	depth int
+}
+
+func (p *parser) next() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return &ParseError{Pos: p.tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) isOperator(ops ...string) bool {
+	if p.tok.kind != tokenOperator {
+		return false
+	}
+	for _, op := range ops {
+		if p.tok.text == op {
+			return true
+		}
+	}
+	return false
+}
+
+// expect checks that the current token is the operator, and moves to the next token.
+func (p *parser) expect(op string) error {
+	if !p.isOperator(op) {
+		return p.errorf("expected %q", op)
+	}
+	return p.next()
+}
+
+// parseExpr parses: term (("+" | "-") term)*.
+func (p *parser) parseExpr() (node, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return node{}, err
+	}
+	for p.isOperator("+", "-") {
+		op := p.tok.text
+		err = p.next()
+		if err != nil {
+			return node{}, err
+		}
+		right, err := p.parseTerm()
+		if err != nil {
+			return node{}, err
+		}
+		left = binaryNode(op, left, right)
+	}
+	return left, nil
+}
+
+// parseTerm parses: unary (("*" | "/") unary)*.
+func (p *parser) parseTerm() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return node{}, err
+	}
+	for p.isOperator("*", "/") {
+		op := p.tok.text
+		err = p.next()
+		if err != nil {
+			return node{}, err
+		}
+		right, err := p.parseUnary()
+		if err != nil {
+			return node{}, err
+		}
+		left = binaryNode(op, left, right)
+	}
+	return left, nil
+}
+
+// parseUnary parses: ("-" | "+") unary | power.
+//
+// All the recursive rules go through it, so it checks the nesting depth.
+func (p *parser) parseUnary() (node, error) {
+	if p.depth >= maxDepth {
+		return node{}, p.errorf("maximum nesting depth %d exceeded", maxDepth)
+	}
+	p.depth++
+	defer func() {
+		p.depth--
+	}()
+	if p.isOperator("-", "+") {
+		op := p.tok.text
+		err := p.next()
+		if err != nil {
+			return node{}, err
+		}
+		n, err := p.parseUnary()
+		if err != nil {
+			return node{}, err
+		}
+		if op == "-" {
+			n = negNode(n)
+		}
+		return n, nil
+	}
+	return p.parsePower()
+}
+
+// parsePower parses: primary ("^" unary)?.
+//
+// The power is right associative, and has a higher precedence than the unary operators on its left.
+func (p *parser) parsePower() (node, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return node{}, err
+	}
+	if !p.isOperator("^") {
+		return base, nil
+	}
+	err = p.next()
+	if err != nil {
+		return node{}, err
+	}
+	exp, err := p.parseUnary()
+	if err != nil {
+		return node{}, err
+	}
+	return powNode(base, exp), nil
+}
+
+// parsePrimary parses: number | variable | function "(" expr ")" | "(" expr ")".
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.tok
+	switch {
+	case tok.kind == tokenNumber:
+		v, err := parseNumber(tok.text)
+		if err != nil {
+			return node{}, p.errorf("invalid number %q", tok.text)
+		}
+		return constNode(v), p.next()
+	case tok.kind == tokenIdent:
+		return p.parseIdent()
+	case p.isOperator("("):
+		err := p.next()
+		if err != nil {
+			return node{}, err
+		}
+		n, err := p.parseExpr()
+		if err != nil {
+			return node{}, err
+		}
+		return n, p.expect(")")
+	case tok.kind == tokenEOF:
+		return node{}, p.errorf("unexpected end of expression")
+	}
+	return node{}, p.errorf("unexpected %q", tok.text)
+}
+
+func (p *parser) parseIdent() (node, error) {
+	tok := p.tok
+	if f, ok := functions[tok.text]; ok {
+		err := p.next()
+		if err != nil {
+			return node{}, err
+		}
+		err = p.expect("(")
+		if err != nil {
+			return node{}, err
+		}
+		arg, err := p.parseExpr()
+		if err != nil {
+			return node{}, err
+		}
+		return funcNode(f, arg), p.expect(")")
+	}
+	if tok.text == "i" {
+		return constNode(1i), p.next()
+	}
+	if v, ok := variables[tok.text]; ok {
+		return node{eval: v}, p.next()
+	}
+	return node{}, p.errorf("unknown identifier %q", tok.text)
+}
+
+func parseNumber(s string) (complex128, error) {
+	imaginary := strings.HasSuffix(s, "i")
+	s = strings.TrimSuffix(s, "i")
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, err //nolint:wrapcheck // It is converted to a ParseError.
+	}
+	if imaginary {
+		return complex(0, f), nil
+	}
+	return complex(f, 0), nil
+}
+
+var variables = map[string]Expr{
+	"z": func(vars Vars) complex128 {
+		return vars.Z
+	},
+	"c": func(vars Vars) complex128 {
+		return vars.C
+	},
+	"k": func(vars Vars) complex128 {
+		return vars.K
+	},
+	"pixel": func(vars Vars) complex128 {
+		return vars.C
+	},
+}
+
+var functions = map[string]func(complex128) complex128{
+	"sin":  cmplx.Sin,
+	"cos":  cmplx.Cos,
+	"tan":  cmplx.Tan,
+	"sinh": cmplx.Sinh,
+	"cosh": cmplx.Cosh,
+	"tanh": cmplx.Tanh,
+	"exp":  cmplx.Exp,
+	"log":  cmplx.Log,
+	"sqrt": cmplx.Sqrt,
+	"conj": cmplx.Conj,
+	"abs": func(z complex128) complex128 {
+		return complex(cmplx.Abs(z), 0)
+	},
+	"re": func(z complex128) complex128 {
+		return complex(real(z), 0)
+	},
+	"im": func(z complex128) complex128 {
+		return complex(imag(z), 0)
+	},
+}
+
+// node is a node of the expression tree.
+//
+// Constant nodes are folded at compile time.
+type node struct {
+	constant bool
+	value    complex128
+	eval     Expr
+}
+
+func constNode(v complex128) node {
+	return node{
+		constant: true,
+		value:    v,
+	}
+}
+
+func (n node) expr() Expr {
+	if n.constant {
+		v := n.value
+		return func(vars Vars) complex128 {
+			return v
+		}
+	}
+	return n.eval
+}
+
+func negNode(n node) node {
+	if n.constant {
+		return constNode(-n.value)
+	}
+	e := n.eval
+	return node{eval: func(vars Vars) complex128 {
+		return -e(vars)
+	}}
+}
+
+func funcNode(f func(complex128) complex128, arg node) node {
+	if arg.constant {
+		return constNode(f(arg.value))
+	}
+	e := arg.eval
+	return node{eval: func(vars Vars) complex128 {
+		return f(e(vars))
+	}}
+}
+
+func binaryNode(op string, left, right node) node {
+	if left.constant && right.constant {
+		return constNode(binaryOp(op, left.value, right.value))
+	}
+	l, r := left.expr(), right.expr()
+	switch op {
+	case "+":
+		return node{eval: func(vars Vars) complex128 {
+			return l(vars) + r(vars)
+		}}
+	case "-":
+		return node{eval: func(vars Vars) complex128 {
+			return l(vars) - r(vars)
+		}}
+	case "*":
+		return node{eval: func(vars Vars) complex128 {
+			return l(vars) * r(vars)
+		}}
+	default:
+		return node{eval: func(vars Vars) complex128 {
+			return l(vars) / r(vars)
+		}}
+	}
+}
+
+func binaryOp(op string, a, b complex128) complex128 {
+	switch op {
+	case "+":
+		return a + b
+	case "-":
+		return a - b
+	case "*":
+		return a * b
+	default:
+		return a / b
+	}
+}
+
+func powNode(base, exp node) node {
+	if base.constant && exp.constant {
+		return constNode(cmplx.Pow(base.value, exp.value))
+	}
+	b := base.expr()
+	if exp.constant && imag(exp.value) == 0 && real(exp.value) == math.Trunc(real(exp.value)) && math.Abs(real(exp.value)) <= math.MaxInt32 {
+		n := int(real(exp.value))
+		return node{eval: func(vars Vars) complex128 {
+			return powInt(b(vars), n)
+		}}
+	}
+	e := exp.expr()
+	return node{eval: func(vars Vars) complex128 {
+		return cmplx.Pow(b(vars), e(vars))
+	}}
+}
+
+// powInt computes z^n with exponentiation by squaring.
+func powInt(z complex128, n int) complex128 {
+	if n < 0 {
+		return 1 / powInt(z, -n)
+	}
+	res := complex(1, 0)
+	for n > 0 {
+		if n&1 != 0 {
+			res *= z
+		}
+		z *= z
+		n >>= 1
+	}
+	return res
+}
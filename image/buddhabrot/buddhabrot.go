@@ -0,0 +1,264 @@
+// Package buddhabrot provides density rendering of the orbits of the Mandelbrot set (Buddhabrot, Nebulabrot, anti-Buddhabrot).
+package buddhabrot
+
+import (
+	"image"
+	"image/draw"
+	"math"
+	"math/rand/v2"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pierrre/imageutil"
+	"github.com/pierrre/mandelbrot"
+	mandelbrot_image "github.com/pierrre/mandelbrot/image"
+)
+
+// Options represents the options of the rendering.
+type Options struct {
+	// Samples is the number of sampled points c.
+	Samples int
+	// Seed is the seed of the random number generator.
+	// The result is deterministic for a given seed, and doesn't depend on the number of CPUs.
+	Seed uint64
+	// Stratified enables the stratified sampling: the area is divided in a grid, with one random sample per cell.
+	// The grid is filled row by row, so Samples should be a square number.
+	// Otherwise, the samples are uniformly random in the area.
+	Stratified bool
+	// AreaMin and AreaMax are the corners of the sampled area.
+	// The default area is from -2-2i to 2+2i.
+	AreaMin, AreaMax complex128
+	// MaxIter is the maximum number of iterations for each channel (red, green, blue).
+	// The Buddhabrot uses the same value for all channels, and the Nebulabrot uses different values.
+	MaxIter [3]int
+	// Power is the power of z.
+	// The default value is 2.
+	Power float64
+	// Anti enables the anti-Buddhabrot: the orbits of the bounded points are recorded, instead of the unbounded points.
+	Anti bool
+}
+
+func (opts Options) withDefaults() Options {
+	if opts.AreaMin == 0 && opts.AreaMax == 0 {
+		opts.AreaMin, opts.AreaMax = complex(-2, -2), complex(2, 2)
+	}
+	if opts.Power == 0 {
+		opts.Power = 2
+	}
+	return opts
+}
+
+// Histogram is the density of the orbits.
+type Histogram struct {
+	// Rect is the rectangle of pixels.
+	Rect image.Rectangle
+	// Channels contains the hit counts of the red, green and blue channels.
+	// A pixel is at the index (y-Rect.Min.Y)*Rect.Dx() + (x-Rect.Min.X).
+	Channels [3][]float64
+}
+
+// NewHistogram returns a new empty [Histogram].
+func NewHistogram(r image.Rectangle) *Histogram {
+	h := &Histogram{
+		Rect: r,
+	}
+	for i := range h.Channels {
+		h.Channels[i] = make([]float64, r.Dx()*r.Dy())
+	}
+	return h
+}
+
+// Add adds the counts of another [Histogram] with the same rectangle.
+func (h *Histogram) Add(other *Histogram) {
+	for i, ch := range h.Channels {
+		for j, v := range other.Channels[i] {
+			ch[j] += v
+		}
+	}
+}
+
+// Max returns the maximum count of each channel.
+func (h *Histogram) Max() [3]float64 {
+	var res [3]float64
+	for i, ch := range h.Channels {
+		for _, v := range ch {
+			res[i] = max(res[i], v)
+		}
+	}
+	return res
+}
+
+// Draw draws the [Histogram] to the image, with the tone mapping.
+//
+// Each channel is normalized by its maximum count.
+func (h *Histogram) Draw(im draw.Image, tm ToneMapping) {
+	maxCounts := h.Max()
+	set := imageutil.NewSetFunc(im)
+	var col [3]uint32
+	for y := h.Rect.Min.Y; y < h.Rect.Max.Y; y++ {
+		for x := h.Rect.Min.X; x < h.Rect.Max.X; x++ {
+			i := (y-h.Rect.Min.Y)*h.Rect.Dx() + (x - h.Rect.Min.X)
+			for j, ch := range h.Channels {
+				v := 0.0
+				if maxCounts[j] > 0 {
+					v = min(max(tm(ch[i], maxCounts[j]), 0), 1)
+				}
+				col[j] = uint32(v * math.MaxUint16)
+			}
+			set(x, y, col[0], col[1], col[2], math.MaxUint16)
+		}
+	}
+}
+
+// ToneMapping converts a count to a value in the range [0, 1], for the given maximum count.
+type ToneMapping func(count, maxCount float64) float64
+
+// LinearToneMapping is a [ToneMapping] that returns count/maxCount.
+func LinearToneMapping(count, maxCount float64) float64 {
+	return count / maxCount
+}
+
+// LogToneMapping is a [ToneMapping] that returns log(1+count)/log(1+maxCount).
+//
+// It shows the low density regions.
+func LogToneMapping(count, maxCount float64) float64 {
+	return math.Log1p(count) / math.Log1p(maxCount)
+}
+
+// SqrtToneMapping is a [ToneMapping] that returns sqrt(count/maxCount).
+func SqrtToneMapping(count, maxCount float64) float64 {
+	return math.Sqrt(count / maxCount)
+}
+
+// GammaToneMapping returns a [ToneMapping] that returns (count/maxCount)^(1/gamma).
+func GammaToneMapping(gamma float64) ToneMapping {
+	invGamma := 1 / gamma
+	return func(count, maxCount float64) float64 {
+		return math.Pow(count/maxCount, invGamma)
+	}
+}
+
+// chunkSize is the number of samples processed by a goroutine at once.
+//
+// Each chunk has its own random number generator, so the result doesn't depend on the scheduling.
+const chunkSize = 1 << 12
+
+// Render returns the [Histogram] of the orbits, for the pixels of the rectangle.
+//
+// tsf transforms a pixel to a point, and it is inverted to transform the values of the orbits to pixels, see [mandelbrot_image.InverseTransformation].
+// The samples are processed in parallel, with a [Histogram] per goroutine.
+func Render(r image.Rectangle, tsf mandelbrot_image.Transformation, opts Options) *Histogram {
+	opts = opts.withDefaults()
+	inv := mandelbrot_image.InverseTransformation(tsf)
+	chunks := (opts.Samples + chunkSize - 1) / chunkSize
+	workers := min(runtime.GOMAXPROCS(0), chunks)
+	hs := make([]*Histogram, workers)
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Go(func() {
+			rd := newRenderer(r, inv, opts)
+			for {
+				chunk := int(next.Add(1) - 1)
+				if chunk >= chunks {
+					break
+				}
+				rd.renderChunk(chunk)
+			}
+			hs[w] = rd.histogram
+		})
+	}
+	wg.Wait()
+	h := NewHistogram(r)
+	for _, wh := range hs {
+		h.Add(wh)
+	}
+	return h
+}
+
+// renderer contains the state of a goroutine.
+type renderer struct {
+	opts      Options
+	inv       mandelbrot_image.Transformation
+	histogram *Histogram
+	f         mandelbrot.OrbitFunc
+	orbit     []complex128
+	grid      int
+}
+
+func newRenderer(r image.Rectangle, inv mandelbrot_image.Transformation, opts Options) *renderer {
+	maxIter := max(opts.MaxIter[0], opts.MaxIter[1], opts.MaxIter[2])
+	mopts := mandelbrot.Options{
+		MaxIter: maxIter,
+		Power:   opts.Power,
+	}
+	if !opts.Anti {
+		// The bounded points are not recorded, so they can stop early.
+		mopts.Periodicity = mandelbrot.DefaultPeriodicity
+	}
+	return &renderer{
+		opts:      opts,
+		inv:       inv,
+		histogram: NewHistogram(r),
+		f:         mandelbrot.NewOrbitFunc(mopts),
+		orbit:     make([]complex128, 0, maxIter+1),
+		grid:      int(math.Ceil(math.Sqrt(float64(opts.Samples)))),
+	}
+}
+
+func (rd *renderer) renderChunk(chunk int) {
+	rnd := rand.New(rand.NewPCG(rd.opts.Seed, uint64(chunk)))
+	start := chunk * chunkSize
+	end := min(start+chunkSize, rd.opts.Samples)
+	for i := start; i < end; i++ {
+		rd.renderSample(rd.sample(i, rnd))
+	}
+}
+
+// sample returns the sampled point for the index.
+func (rd *renderer) sample(i int, rnd *rand.Rand) complex128 {
+	u, v := rnd.Float64(), rnd.Float64()
+	if rd.opts.Stratified {
+		u = (float64(i%rd.grid) + u) / float64(rd.grid)
+		v = (float64(i/rd.grid) + v) / float64(rd.grid)
+	}
+	size := rd.opts.AreaMax - rd.opts.AreaMin
+	return rd.opts.AreaMin + complex(u*real(size), v*imag(size))
+}
+
+func (rd *renderer) renderSample(c complex128) {
+	rd.orbit = rd.orbit[:0]
+	res := rd.f(c, rd.visit)
+	for ch, maxIter := range rd.opts.MaxIter {
+		escaped := !res.Bounded && res.Iter < maxIter
+		if escaped == rd.opts.Anti || maxIter <= 0 {
+			continue
+		}
+		n := min(len(rd.orbit), maxIter)
+		if escaped {
+			n = res.Iter + 1
+		}
+		// The first value is the sampled point, it is not recorded because it is uniformly distributed.
+		rd.record(ch, rd.orbit[1:n])
+	}
+}
+
+func (rd *renderer) visit(iter int, z complex128) {
+	rd.orbit = append(rd.orbit, z)
+}
+
+// record increments the count of the pixels of the orbit.
+func (rd *renderer) record(ch int, orbit []complex128) {
+	h := rd.histogram
+	counts := h.Channels[ch]
+	for _, z := range orbit {
+		p := rd.inv(z)
+		x := int(math.Floor(real(p) + 0.5))
+		y := int(math.Floor(imag(p) + 0.5))
+		if !image.Pt(x, y).In(h.Rect) {
+			continue
+		}
+		counts[(y-h.Rect.Min.Y)*h.Rect.Dx()+(x-h.Rect.Min.X)]++
+	}
+}
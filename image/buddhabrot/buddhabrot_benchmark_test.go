@@ -0,0 +1,24 @@
+package buddhabrot
+
+import (
+	"image"
+	"testing"
+
+	mandelbrot_image "github.com/pierrre/mandelbrot/image"
+)
+
+func BenchmarkRender(b *testing.B) {
+	size := image.Pt(256, 256)
+	r := image.Rect(0, 0, size.X, size.Y)
+	im := image.NewRGBA(r)
+	tsf := mandelbrot_image.BaseTransformation(im, 0, mandelbrot_image.Scale(size), 0)
+	opts := Options{
+		Samples: 1 << 14,
+		Seed:    1,
+		MaxIter: [3]int{1000, 100, 10},
+	}
+	for b.Loop() {
+		h := Render(r, tsf, opts)
+		h.Draw(im, SqrtToneMapping)
+	}
+}
@@ -2,6 +2,8 @@ package image
 
 import (
 	"image/color"
+	"math"
+	"math/cmplx"
 
 	"github.com/pierrre/mandelbrot"
 )
@@ -24,6 +26,50 @@ func ColorsIterColorizer(cols []color.Color, shift int) Colorizer {
 	}
 }
 
+// ColorsSmoothColorizer returns a [Colorizer] that uses a list of colors, and interpolates between them with the smooth iteration count.
+// It requires [mandelbrot.Options.Smooth] or [mandelbrot.Func.WithSmooth], except for the Newton fractals.
+// It panics if cols is empty.
+func ColorsSmoothColorizer(cols []color.Color, shift float64) Colorizer {
+	interpolate := newColorsInterpolator(cols)
+	return func(c complex128, res mandelbrot.Result) color.Color {
+		return interpolate(res.Smooth + shift)
+	}
+}
+
+// newColorsInterpolator returns a function that interpolates the colors at a position.
+// The position is periodic, and each color is at an integer position.
+func newColorsInterpolator(cols []color.Color) func(float64) color.Color {
+	rgbas := make([]color.RGBA64, len(cols))
+	for i, col := range cols {
+		rgbas[i] = toRGBA64(col)
+	}
+	return func(v float64) color.Color {
+		fl := math.Floor(v)
+		i := int(fl) % len(rgbas)
+		if i < 0 {
+			i += len(rgbas)
+		}
+		return lerpRGBA64(rgbas[i], rgbas[(i+1)%len(rgbas)], v-fl)
+	}
+}
+
+func toRGBA64(col color.Color) color.RGBA64 {
+	return color.RGBA64Model.Convert(col).(color.RGBA64) //nolint:forcetypeassert // The model always returns this type.
+}
+
+func lerpRGBA64(c1, c2 color.RGBA64, t float64) color.RGBA64 {
+	return color.RGBA64{
+		R: lerpUint16(c1.R, c2.R, t),
+		G: lerpUint16(c1.G, c2.G, t),
+		B: lerpUint16(c1.B, c2.B, t),
+		A: lerpUint16(c1.A, c2.A, t),
+	}
+}
+
+func lerpUint16(a, b uint16, t float64) uint16 {
+	return uint16(float64(a) + (float64(b)-float64(a))*t)
+}
+
 // BoundColorizer returns a [Colorizer] that uses a bounded color for bounded points and an unbounded color for unbounded points.
 func BoundColorizer(bounded, unbounded Colorizer) Colorizer {
 	return func(c complex128, res mandelbrot.Result) color.Color {
@@ -43,3 +89,92 @@ func BWColorizer(invert bool) Colorizer {
 	}
 	return BoundColorizer(bounded, unbounded)
 }
+
+// DistanceColorizer returns a [Colorizer] that uses the distance estimation to draw the boundary of the set.
+//
+// The near colorizer is used for bounded points, and for unbounded points whose distance is lower than threshold pixels.
+// The far colorizer is used for other points.
+// The pixel size can be computed with [PixelSize].
+// It requires [mandelbrot.Options.Distance].
+func DistanceColorizer(pixelSize, threshold float64, near, far Colorizer) Colorizer {
+	maxDistance := pixelSize * threshold
+	return func(c complex128, res mandelbrot.Result) color.Color {
+		if res.Bounded || res.Distance < maxDistance {
+			return near(c, res)
+		}
+		return far(c, res)
+	}
+}
+
+// PeriodColorizer returns a [Colorizer] that uses a list of colors, indexed by the period of the attracting cycle.
+// It panics if cols is empty.
+// It requires [mandelbrot.Options.Periodicity].
+func PeriodColorizer(cols []color.Color) Colorizer {
+	return func(c complex128, res mandelbrot.Result) color.Color {
+		return cols[res.Period%len(cols)]
+	}
+}
+
+// MultiplierColorizer returns a [Colorizer] that uses a list of colors, and interpolates between them with the angle of the multiplier of the attracting cycle.
+// It panics if cols is empty.
+// It requires [mandelbrot.Options.Interior].
+func MultiplierColorizer(cols []color.Color) Colorizer {
+	interpolate := newColorsInterpolator(cols)
+	return func(c complex128, res mandelbrot.Result) color.Color {
+		angle := cmplx.Phase(res.Multiplier) / (2 * math.Pi)
+		return interpolate(angle * float64(len(cols)))
+	}
+}
+
+// InteriorDistanceColorizer returns a [Colorizer] that shades the bounded points with the interior distance estimation.
+//
+// The edge color is used at the boundary of the set, and the inner color at a distance greater than threshold pixels.
+// The pixel size can be computed with [PixelSize].
+// It requires [mandelbrot.Options.Interior].
+func InteriorDistanceColorizer(pixelSize, threshold float64, edge, inner color.Color) Colorizer {
+	maxDistance := pixelSize * threshold
+	edgeRGBA := toRGBA64(edge)
+	innerRGBA := toRGBA64(inner)
+	return func(c complex128, res mandelbrot.Result) color.Color {
+		t := min(max(res.InteriorDistance/maxDistance, 0), 1)
+		return lerpRGBA64(edgeRGBA, innerRGBA, t)
+	}
+}
+
+// RootColorizer returns a [Colorizer] that uses a list of colors, indexed by the root that the point converged to.
+//
+// The color is darkened with the number of steps: shading is the darkening per step, and 0 disables it.
+// Points that didn't converge are black.
+// It panics if cols is empty.
+// It should be used with [mandelbrot.NewNewton].
+func RootColorizer(cols []color.Color, shading float64) Colorizer {
+	rgbas := make([]color.RGBA64, len(cols))
+	for i, col := range cols {
+		rgbas[i] = toRGBA64(col)
+	}
+	black := toRGBA64(color.Black)
+	return func(c complex128, res mandelbrot.Result) color.Color {
+		if res.Root < 0 {
+			return black
+		}
+		t := min(max(res.Smooth*shading, 0), 1)
+		return lerpRGBA64(rgbas[res.Root%len(rgbas)], black, t)
+	}
+}
+
+// LyapunovColorizer returns a [Colorizer] for the Lyapunov fractal, see [mandelbrot.NewLyapunov].
+//
+// The stable points (negative exponent) are colored with stable, and the chaotic points (positive exponent) with chaotic.
+// The color is interpolated from black (exponent 0) with the absolute value of the exponent divided by scale, limited to 1.
+func LyapunovColorizer(stable, chaotic color.Color, scale float64) Colorizer {
+	black := toRGBA64(color.Black)
+	stableRGBA := toRGBA64(stable)
+	chaoticRGBA := toRGBA64(chaotic)
+	return func(c complex128, res mandelbrot.Result) color.Color {
+		t := min(math.Abs(res.Exponent)/scale, 1)
+		if res.Exponent < 0 {
+			return lerpRGBA64(black, stableRGBA, t)
+		}
+		return lerpRGBA64(black, chaoticRGBA, t)
+	}
+}
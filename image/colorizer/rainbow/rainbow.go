@@ -3,8 +3,10 @@ package rainbow
 
 import (
 	"image/color"
+	"math"
 
 	"github.com/lucasb-eyer/go-colorful"
+	"github.com/pierrre/mandelbrot"
 	mandelbrot_image "github.com/pierrre/mandelbrot/image"
 )
 
@@ -16,3 +18,16 @@ func Colorizer(colorCount int, shift int) mandelbrot_image.Colorizer {
 	}
 	return mandelbrot_image.ColorsIterColorizer(cols, shift)
 }
+
+// SmoothColorizer returns a [mandelbrot_image.Colorizer] that uses a continuous rainbow color scheme.
+// It uses [mandelbrot.Result.Smooth], so there is no visible banding.
+// It requires [mandelbrot.Options.Smooth] or [mandelbrot.Func.WithSmooth], except for the Newton fractals.
+func SmoothColorizer(colorCount int, shift float64) mandelbrot_image.Colorizer {
+	return func(c complex128, res mandelbrot.Result) color.Color {
+		h := math.Mod((res.Smooth+shift)/float64(colorCount), 1)
+		if h < 0 {
+			h++
+		}
+		return colorful.Hsv(h*360, 1, 1)
+	}
+}
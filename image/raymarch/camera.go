@@ -0,0 +1,49 @@
+package raymarch
+
+import (
+	"image"
+	"math"
+)
+
+// Camera represents the point of view.
+type Camera struct {
+	// Position is the position of the camera.
+	Position Vec3
+	// Target is the point that the camera looks at.
+	Target Vec3
+	// Up is the up direction.
+	// The default value is (0, 1, 0).
+	Up Vec3
+	// FOV is the vertical field of view, in radians.
+	// The default value is pi/4.
+	FOV float64
+}
+
+func (cam Camera) withDefaults() Camera {
+	if cam.Up == (Vec3{}) {
+		cam.Up = Vec3{0, 1, 0}
+	}
+	if cam.FOV == 0 {
+		cam.FOV = math.Pi / 4
+	}
+	return cam
+}
+
+// rayFunc returns the direction of the ray for a pixel.
+type rayFunc func(x, y int) Vec3
+
+// rays returns a [rayFunc] for the image bounds.
+func (cam Camera) rays(bds image.Rectangle) rayFunc {
+	cam = cam.withDefaults()
+	forward := cam.Target.Sub(cam.Position).Normalize()
+	right := forward.Cross(cam.Up).Normalize()
+	up := right.Cross(forward)
+	size := bds.Size()
+	scale := math.Tan(cam.FOV/2) * 2 / float64(size.Y)
+	center := Vec3{float64(bds.Min.X) + float64(size.X)/2, float64(bds.Min.Y) + float64(size.Y)/2, 0}
+	return func(x, y int) Vec3 {
+		u := (float64(x) + 0.5 - center.X) * scale
+		v := (center.Y - float64(y) - 0.5) * scale
+		return forward.Add(right.Mul(u)).Add(up.Mul(v)).Normalize()
+	}
+}
@@ -0,0 +1,87 @@
+package raymarch
+
+import (
+	"math"
+)
+
+// DistanceEstimator returns a lower bound of the distance from the point to the surface of the fractal.
+//
+// It returns a value lower than or equal to 0 if the point is inside.
+type DistanceEstimator func(p Vec3) float64
+
+// mandelbulbBailout is the escape radius of the Mandelbulb.
+const mandelbulbBailout = 2
+
+// NewMandelbulb returns a new [DistanceEstimator] for the Mandelbulb of the given power.
+//
+// It is the 3D extension of [github.com/pierrre/mandelbrot.NewPow], with the spherical coordinates: the power of a point multiplies its angles and raises its radius.
+// The power 8 is the most common.
+func NewMandelbulb(maxIter int, power float64) DistanceEstimator {
+	return func(p Vec3) float64 {
+		z := p
+		dr := 1.0
+		r := z.Length()
+		for range maxIter {
+			if r > mandelbulbBailout {
+				break
+			}
+			theta := math.Acos(z.Z/r) * power
+			phi := math.Atan2(z.Y, z.X) * power
+			rp := math.Pow(r, power-1)
+			dr = rp*power*dr + 1
+			rp *= r
+			sinTheta, cosTheta := math.Sincos(theta)
+			sinPhi, cosPhi := math.Sincos(phi)
+			z = Vec3{sinTheta * cosPhi, sinTheta * sinPhi, cosTheta}.Mul(rp).Add(p)
+			r = z.Length()
+		}
+		if r == 0 {
+			return 0
+		}
+		return 0.5 * math.Log(r) * r / dr
+	}
+}
+
+const (
+	mandelboxMinRadiusSquare   = 0.25
+	mandelboxFixedRadiusSquare = 1
+)
+
+// NewMandelbox returns a new [DistanceEstimator] for the Mandelbox of the given scale.
+//
+// Each iteration applies a box fold, a sphere fold, then z = scale*z + p.
+// The scale -1.5 and 2 are common.
+func NewMandelbox(maxIter int, scale float64) DistanceEstimator {
+	return func(p Vec3) float64 {
+		z := p
+		dr := 1.0
+		for range maxIter {
+			z = Vec3{boxFold(z.X), boxFold(z.Y), boxFold(z.Z)}
+			r2 := z.Dot(z)
+			switch {
+			case r2 < mandelboxMinRadiusSquare:
+				f := mandelboxFixedRadiusSquare / mandelboxMinRadiusSquare
+				z = z.Mul(f)
+				dr *= f
+			case r2 < mandelboxFixedRadiusSquare:
+				f := mandelboxFixedRadiusSquare / r2
+				z = z.Mul(f)
+				dr *= f
+			}
+			z = z.Mul(scale).Add(p)
+			dr = dr*math.Abs(scale) + 1
+		}
+		return z.Length() / math.Abs(dr)
+	}
+}
+
+// boxFold folds the value in the range [-1, 1].
+func boxFold(v float64) float64 {
+	if v > 1 {
+		return 2 - v
+	}
+	if v < -1 {
+		return -2 - v
+	}
+	return v
+}
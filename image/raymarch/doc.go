@@ -0,0 +1,4 @@
+// Package raymarch provides 3D rendering of fractals, with distance-estimated ray marching.
+//
+// It supports the Mandelbulb, the Mandelbox, and the 3D slices of the quaternion and bicomplex Julia sets, see [NewMandelbulb], [NewMandelbox], [NewQuaternionJulia] and [NewBicomplexJulia].
+package raymarch
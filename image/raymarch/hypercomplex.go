@@ -0,0 +1,107 @@
+package raymarch
+
+import (
+	"math"
+)
+
+// Quaternion is a quaternion A + B*i + C*j + D*k.
+type Quaternion struct {
+	A, B, C, D float64
+}
+
+// Add returns q + r.
+func (q Quaternion) Add(r Quaternion) Quaternion {
+	return Quaternion{q.A + r.A, q.B + r.B, q.C + r.C, q.D + r.D}
+}
+
+// Mul returns q * r.
+func (q Quaternion) Mul(r Quaternion) Quaternion {
+	return Quaternion{
+		q.A*r.A - q.B*r.B - q.C*r.C - q.D*r.D,
+		q.A*r.B + q.B*r.A + q.C*r.D - q.D*r.C,
+		q.A*r.C - q.B*r.D + q.C*r.A + q.D*r.B,
+		q.A*r.D + q.B*r.C - q.C*r.B + q.D*r.A,
+	}
+}
+
+// NormSquare returns the square of the norm of q.
+func (q Quaternion) NormSquare() float64 {
+	return q.A*q.A + q.B*q.B + q.C*q.C + q.D*q.D
+}
+
+const (
+	// hypercomplexBailoutSquare is the square of the escape radius of the hypercomplex Julia sets.
+	// A large value gives a more accurate distance.
+	hypercomplexBailoutSquare = 256
+)
+
+// NewQuaternionJulia returns a new [DistanceEstimator] for the 3D slice of the quaternion Julia set for the constant c.
+//
+// The formula is q = q^2 + c.
+// The point (x, y, z) is mapped to the quaternion x + y*i + z*j + slice*k, so slice selects the 3D slice of the 4D set.
+// It returns 0 for the bounded points.
+func NewQuaternionJulia(maxIter int, c Quaternion, slice float64) DistanceEstimator {
+	return func(p Vec3) float64 {
+		q := Quaternion{p.X, p.Y, p.Z, slice}
+		m2 := q.NormSquare()
+		// square of the norm of the derivative
+		dm2 := 1.0
+		for range maxIter {
+			if m2 > hypercomplexBailoutSquare {
+				return juliaDistance(m2, dm2)
+			}
+			dm2 *= 4 * m2
+			q = q.Mul(q).Add(c)
+			m2 = q.NormSquare()
+		}
+		if m2 > hypercomplexBailoutSquare {
+			return juliaDistance(m2, dm2)
+		}
+		return 0
+	}
+}
+
+// NewBicomplexJulia returns a new [DistanceEstimator] for the 3D slice of the bicomplex Julia set for the constant c1 + c2*j.
+//
+// The bicomplex numbers are z1 + z2*j, where z1 and z2 are complex numbers, and i*j = j*i.
+// The formula is z = z^2 + c.
+// The point (x, y, z) is mapped to z1 = x + y*i and z2 = z + slice*i, so slice selects the 3D slice of the 4D set.
+//
+// It uses the idempotent decomposition: z1 - i*z2 and z1 + i*z2 are iterated independently as complex Julia sets.
+func NewBicomplexJulia(maxIter int, c1, c2 complex128, slice float64) DistanceEstimator {
+	k1, k2 := c1-1i*c2, c1+1i*c2
+	return func(p Vec3) float64 {
+		z1, z2 := complex(p.X, p.Y), complex(p.Z, slice)
+		d1 := complexJuliaDistance(z1-1i*z2, k1, maxIter)
+		d2 := complexJuliaDistance(z1+1i*z2, k2, maxIter)
+		// The decomposition scales the distances by sqrt(2).
+		return max(d1, d2) / math.Sqrt2
+	}
+}
+
+// complexJuliaDistance returns the estimated distance of the point to the complex Julia set for the constant k.
+//
+// It returns 0 if the point is bounded.
+func complexJuliaDistance(z, k complex128, maxIter int) float64 {
+	m2 := real(z)*real(z) + imag(z)*imag(z)
+	dm2 := 1.0
+	for range maxIter {
+		if m2 > hypercomplexBailoutSquare {
+			return juliaDistance(m2, dm2)
+		}
+		dm2 *= 4 * m2
+		z = z*z + k
+		m2 = real(z)*real(z) + imag(z)*imag(z)
+	}
+	if m2 > hypercomplexBailoutSquare {
+		return juliaDistance(m2, dm2)
+	}
+	return 0
+}
+
+// juliaDistance returns the estimated distance for the square of the norm of z, and the square of the norm of its derivative.
+//
+// It is |z|*log(|z|)/(2*|dz|).
+func juliaDistance(m2, dm2 float64) float64 {
+	return 0.25 * math.Log(m2) * math.Sqrt(m2/dm2)
+}
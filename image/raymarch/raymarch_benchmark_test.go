@@ -0,0 +1,55 @@
+package raymarch
+
+import (
+	"image"
+	"testing"
+)
+
+func BenchmarkRender(b *testing.B) {
+	im := image.NewRGBA(image.Rect(0, 0, 64, 64))
+	cam := Camera{
+		Position: Vec3{0.3, 1.4, -2.2},
+	}
+	de := NewMandelbulb(12, 8)
+	opts := Options{
+		Ambient:          0.1,
+		Specular:         0.5,
+		Shadow:           8,
+		AmbientOcclusion: true,
+	}
+	for b.Loop() {
+		Render(im, cam, de, opts)
+	}
+}
+
+func BenchmarkMandelbulb(b *testing.B) {
+	de := NewMandelbulb(12, 8)
+	p := Vec3{0.5, 0.5, 0.5}
+	for b.Loop() {
+		de(p)
+	}
+}
+
+func BenchmarkMandelbox(b *testing.B) {
+	de := NewMandelbox(15, -1.5)
+	p := Vec3{0.5, 0.5, 0.5}
+	for b.Loop() {
+		de(p)
+	}
+}
+
+func BenchmarkQuaternionJulia(b *testing.B) {
+	de := NewQuaternionJulia(12, Quaternion{-0.2, 0.6, 0.2, 0.2}, 0)
+	p := Vec3{0.5, 0.5, 0.5}
+	for b.Loop() {
+		de(p)
+	}
+}
+
+func BenchmarkBicomplexJulia(b *testing.B) {
+	de := NewBicomplexJulia(12, complex(-0.2, 0.6), complex(0.1, 0), 0)
+	p := Vec3{0.5, 0.5, 0.5}
+	for b.Loop() {
+		de(p)
+	}
+}
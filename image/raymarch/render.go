@@ -0,0 +1,218 @@
+package raymarch
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"math"
+
+	"github.com/pierrre/imageutil"
+)
+
+// Options represents the options of the rendering.
+type Options struct {
+	// MaxSteps is the maximum number of steps of a ray.
+	// The default value is 256.
+	MaxSteps int
+	// MaxDistance is the distance after which a ray misses.
+	// The default value is 10.
+	MaxDistance float64
+	// Epsilon is the distance to the surface under which a ray hits, relative to the distance traveled by the ray.
+	// A lower value gives more details.
+	// The default value is 1e-3.
+	Epsilon float64
+	// Light is the direction to the light.
+	// The default value is (1, 1, -1).
+	Light Vec3
+	// Color is the color of the surface.
+	// The default value is white.
+	Color color.Color
+	// Background is the color of the rays that miss.
+	// The default value is black.
+	Background color.Color
+	// Ambient is the intensity of the ambient light.
+	// 0 disables the ambient light, a recommended value is 0.1.
+	Ambient float64
+	// Specular is the intensity of the specular highlight (Phong).
+	// 0 disables the specular highlight, a recommended value is 0.5.
+	Specular float64
+	// Shininess is the exponent of the specular highlight (Phong).
+	// The default value is 32.
+	Shininess float64
+	// Shadow is the softness of the shadows: a lower value gives softer shadows.
+	// 0 disables the shadows.
+	Shadow float64
+	// AmbientOcclusion enables the ambient occlusion, which darkens the cavities.
+	AmbientOcclusion bool
+}
+
+func (opts Options) withDefaults() Options {
+	if opts.MaxSteps == 0 {
+		opts.MaxSteps = 256
+	}
+	if opts.MaxDistance == 0 {
+		opts.MaxDistance = 10
+	}
+	if opts.Epsilon == 0 {
+		opts.Epsilon = 1e-3
+	}
+	if opts.Light == (Vec3{}) {
+		opts.Light = Vec3{1, 1, -1}
+	}
+	if opts.Color == nil {
+		opts.Color = color.White
+	}
+	if opts.Background == nil {
+		opts.Background = color.Black
+	}
+	if opts.Shininess == 0 {
+		opts.Shininess = 32
+	}
+	return opts
+}
+
+// Render renders the fractal to an image.
+func Render(im draw.Image, cam Camera, de DistanceEstimator, opts Options) {
+	newRenderer(im, cam, de, opts).render(im.Bounds())
+}
+
+// RenderParallel renders the fractal to an image in parallel.
+func RenderParallel(im draw.Image, cam Camera, de DistanceEstimator, opts Options) {
+	rd := newRenderer(im, cam, de, opts)
+	imageutil.Parallel2D(im.Bounds(), rd.render)
+}
+
+type renderer struct {
+	set        imageutil.SetFunc
+	origin     Vec3
+	rays       rayFunc
+	de         DistanceEstimator
+	opts       Options
+	light      Vec3
+	color      [3]float64
+	background [3]uint32
+}
+
+func newRenderer(im draw.Image, cam Camera, de DistanceEstimator, opts Options) *renderer {
+	opts = opts.withDefaults()
+	r, g, b, _ := opts.Color.RGBA()
+	br, bg, bb, _ := opts.Background.RGBA()
+	return &renderer{
+		set:        imageutil.NewSetFunc(im),
+		origin:     cam.Position,
+		rays:       cam.rays(im.Bounds()),
+		de:         de,
+		opts:       opts,
+		light:      opts.Light.Normalize(),
+		color:      [3]float64{float64(r), float64(g), float64(b)},
+		background: [3]uint32{br, bg, bb},
+	}
+}
+
+func (rd *renderer) render(bds image.Rectangle) {
+	for y := bds.Min.Y; y < bds.Max.Y; y++ {
+		for x := bds.Min.X; x < bds.Max.X; x++ {
+			dir := rd.rays(x, y)
+			t, hit := rd.march(rd.origin, dir)
+			if !hit {
+				rd.set(x, y, rd.background[0], rd.background[1], rd.background[2], math.MaxUint16)
+				continue
+			}
+			l := rd.shade(rd.origin.Add(dir.Mul(t)), dir, t)
+			rd.set(x, y, rd.channel(0, l), rd.channel(1, l), rd.channel(2, l), math.MaxUint16)
+		}
+	}
+}
+
+func (rd *renderer) channel(i int, l float64) uint32 {
+	return uint32(min(rd.color[i]*l, math.MaxUint16))
+}
+
+// march returns the distance of the hit along the ray.
+func (rd *renderer) march(origin, dir Vec3) (float64, bool) {
+	t := 0.0
+	for range rd.opts.MaxSteps {
+		d := rd.de(origin.Add(dir.Mul(t)))
+		if d < rd.opts.Epsilon*max(t, 1) {
+			return t, true
+		}
+		t += d
+		if t > rd.opts.MaxDistance {
+			break
+		}
+	}
+	return t, false
+}
+
+// shade returns the intensity of the light at the point, with Phong shading.
+func (rd *renderer) shade(p, dir Vec3, t float64) float64 {
+	eps := rd.opts.Epsilon * max(t, 1)
+	n := rd.normal(p, eps)
+	// Move away from the surface, to avoid self-intersections.
+	p = p.Add(n.Mul(eps * 2))
+	ambient := rd.opts.Ambient
+	if ambient > 0 && rd.opts.AmbientOcclusion {
+		ambient *= rd.ambientOcclusion(p, n, eps)
+	}
+	diffuse := max(n.Dot(rd.light), 0)
+	if diffuse > 0 && rd.opts.Shadow > 0 {
+		diffuse *= rd.softShadow(p, eps)
+	}
+	specular := 0.0
+	if diffuse > 0 && rd.opts.Specular > 0 {
+		reflected := rd.light.Sub(n.Mul(2 * n.Dot(rd.light)))
+		specular = rd.opts.Specular * math.Pow(max(reflected.Dot(dir), 0), rd.opts.Shininess)
+	}
+	return ambient + diffuse + specular
+}
+
+// normal returns the normal of the surface, with the gradient of the distance (tetrahedron technique).
+func (rd *renderer) normal(p Vec3, eps float64) Vec3 {
+	k1 := Vec3{1, -1, -1}
+	k2 := Vec3{-1, -1, 1}
+	k3 := Vec3{-1, 1, -1}
+	k4 := Vec3{1, 1, 1}
+	return k1.Mul(rd.de(p.Add(k1.Mul(eps)))).
+		Add(k2.Mul(rd.de(p.Add(k2.Mul(eps))))).
+		Add(k3.Mul(rd.de(p.Add(k3.Mul(eps))))).
+		Add(k4.Mul(rd.de(p.Add(k4.Mul(eps))))).
+		Normalize()
+}
+
+// softShadow returns the visibility of the light from the point, in the range [0, 1].
+//
+// The penumbra is estimated with the closest distance of the shadow ray to the surface.
+func (rd *renderer) softShadow(p Vec3, eps float64) float64 {
+	res := 1.0
+	t := eps
+	for range rd.opts.MaxSteps {
+		d := rd.de(p.Add(rd.light.Mul(t)))
+		if d < eps {
+			return 0
+		}
+		res = min(res, rd.opts.Shadow*d/t)
+		t += d
+		if t > rd.opts.MaxDistance {
+			break
+		}
+	}
+	return res
+}
+
+// ambientOcclusionSteps is the number of samples along the normal for the ambient occlusion.
+const ambientOcclusionSteps = 5
+
+// ambientOcclusion returns the ambient visibility of the point, in the range [0, 1].
+//
+// It compares the distance to the surface with the distance along the normal: they are equal if there is no occluder.
+func (rd *renderer) ambientOcclusion(p, n Vec3, eps float64) float64 {
+	occlusion := 0.0
+	weight := 1.0
+	step := max(eps*10, 0.01)
+	for i := 1; i <= ambientOcclusionSteps; i++ {
+		h := step * float64(i)
+		occlusion += weight * (h - rd.de(p.Add(n.Mul(h))))
+		weight /= 2
+	}
+	return min(max(1-occlusion/step, 0), 1)
+}
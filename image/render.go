@@ -33,3 +33,34 @@ func render(set imageutil.SetFunc, bds image.Rectangle, tsf Transformation, f ma
 		}
 	}
 }
+
+// RenderBatch renders to an image, by computing the points of each row with a batch.
+//
+// It is faster than [Render] with a batch that iterates several points in lockstep, see [mandelbrot.NewBatch].
+func RenderBatch(im draw.Image, tsf Transformation, f mandelbrot.FuncBatch, clr Colorizer) {
+	renderBatch(imageutil.NewSetFunc(im), im.Bounds(), tsf, f, clr)
+}
+
+// RenderParallelBatch renders to an image in parallel, by computing the points of each row with a batch.
+func RenderParallelBatch(im draw.Image, tsf Transformation, f mandelbrot.FuncBatch, clr Colorizer) {
+	set := imageutil.NewSetFunc(im)
+	imageutil.Parallel2D(im.Bounds(), func(bds image.Rectangle) {
+		renderBatch(set, bds, tsf, f, clr)
+	})
+}
+
+func renderBatch(set imageutil.SetFunc, bds image.Rectangle, tsf Transformation, f mandelbrot.FuncBatch, clr Colorizer) {
+	cs := make([]complex128, bds.Dx())
+	out := make([]mandelbrot.Result, bds.Dx())
+	for y := bds.Min.Y; y < bds.Max.Y; y++ {
+		for i := range cs {
+			cs[i] = tsf(complex(float64(bds.Min.X+i), float64(y)))
+		}
+		f(cs, out)
+		for i, c := range cs {
+			col := clr(c, out[i])
+			r, g, b, a := col.RGBA()
+			set(bds.Min.X+i, y, r, g, b, a)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package image
 
 import (
 	"image"
+	"image/color"
+	"strconv"
 	"testing"
 
 	"github.com/pierrre/mandelbrot"
@@ -30,9 +32,55 @@ func BenchmarkRenderParallel(b *testing.B) {
 	translate := complex(0, 0)
 	tsf := BaseTransformation(im, rotate, scale, translate)
 	maxIter := 500
-	f := mandelbrot.New(maxIter)
 	clr := BWColorizer(false)
+	b.Run("Func", func(b *testing.B) {
+		f := mandelbrot.New(maxIter)
+		for b.Loop() {
+			RenderParallel(im, tsf, f, clr)
+		}
+	})
+	b.Run("Batch", func(b *testing.B) {
+		f := mandelbrot.NewBatch(maxIter)
+		for b.Loop() {
+			RenderParallelBatch(im, tsf, f, clr)
+		}
+	})
+}
+
+func BenchmarkRenderDistance(b *testing.B) {
+	size := image.Pt(256, 256)
+	im := image.NewGray(image.Rect(0, 0, size.X, size.Y))
+	rotate := 1.0
+	scale := Scale(size)
+	translate := complex(0, 0)
+	tsf := BaseTransformation(im, rotate, scale, translate)
+	maxIter := 500
+	f := mandelbrot.NewWithOptions(mandelbrot.Options{
+		MaxIter:  maxIter,
+		Bailout:  1 << 8,
+		Distance: true,
+	})
+	clr := DistanceColorizer(PixelSize(tsf), 0.5, ColorColorizer(color.Black), ColorColorizer(color.White))
 	for b.Loop() {
-		RenderParallel(im, tsf, f, clr)
+		Render(im, tsf, f, clr)
+	}
+}
+
+func BenchmarkRenderSimple(b *testing.B) {
+	for _, pow := range []float64{2, 3, 8} {
+		b.Run(strconv.FormatFloat(pow, 'f', -1, 64), func(b *testing.B) {
+			size := image.Pt(256, 256)
+			im := image.NewGray(image.Rect(0, 0, size.X, size.Y))
+			rotate := 0.0
+			scale := 1.6 * Scale(size)
+			translate := complex(-0.75, 0)
+			tsf := BaseTransformation(im, rotate, scale, translate)
+			maxIter := MaxIter(scale)
+			f := mandelbrot.NewPow(maxIter, pow)
+			clr := BWColorizer(false)
+			for b.Loop() {
+				Render(im, tsf, f, clr)
+			}
+		})
 	}
 }
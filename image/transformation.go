@@ -12,6 +12,29 @@ func identityTransformation(c complex128) complex128 {
 	return c
 }
 
+// PixelSize returns the size of a pixel after the [Transformation].
+//
+// It assumes that the transformation is affine (it is the case for all transformations in this package).
+func PixelSize(tsf Transformation) float64 {
+	return cmplx.Abs(tsf(1) - tsf(0))
+}
+
+// InverseTransformation returns the inverse of the [Transformation].
+//
+// It assumes that the transformation is affine (it is the case for all transformations in this package).
+func InverseTransformation(tsf Transformation) Transformation {
+	o := tsf(0)
+	a := tsf(1) - o
+	b := tsf(1i) - o
+	det := real(a)*imag(b) - real(b)*imag(a)
+	return func(c complex128) complex128 {
+		d := c - o
+		x := (imag(b)*real(d) - real(b)*imag(d)) / det
+		y := (real(a)*imag(d) - imag(a)*real(d)) / det
+		return complex(x, y)
+	}
+}
+
 // BaseTransformation returns a [Transformation] function for the given parameters.
 func BaseTransformation(im image.Image, rotate, scale float64, translate complex128) Transformation {
 	it := ImageTransformation(im)
@@ -0,0 +1,84 @@
+package image
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/pierrre/mandelbrot"
+)
+
+// TrapColorizer returns a [Colorizer] that uses a list of colors, and interpolates between them with the orbit trap distance.
+//
+// The first color is used at distance 0, and the last color at maxDistance and beyond.
+// It panics if cols is empty.
+// It requires [mandelbrot.Options.Trap].
+func TrapColorizer(cols []color.Color, maxDistance float64) Colorizer {
+	interpolate := newColorsInterpolator(cols)
+	last := float64(len(cols) - 1)
+	return func(c complex128, res mandelbrot.Result) color.Color {
+		t := res.TrapDistance / maxDistance
+		if t >= 1 {
+			// Avoid the interpolation with the first color.
+			return cols[len(cols)-1]
+		}
+		return interpolate(t * last)
+	}
+}
+
+// StalksColorizer returns a [Colorizer] that draws the Pickover stalks on top of another [Colorizer].
+//
+// The stalk color is used for points whose trap distance is lower than width, and is blended with the other color near the edge.
+// It should be used with [mandelbrot.CrossTrap].
+func StalksColorizer(width float64, stalk color.Color, other Colorizer) Colorizer {
+	stalkRGBA := toRGBA64(stalk)
+	return func(c complex128, res mandelbrot.Result) color.Color {
+		col := other(c, res)
+		t := res.TrapDistance / width
+		if t >= 1 {
+			return col
+		}
+		return lerpRGBA64(stalkRGBA, toRGBA64(col), t)
+	}
+}
+
+// ImageTrap returns a [mandelbrot.Trap] for an image.
+//
+// The image is centered at center, and scale is the number of pixels per unit.
+// The distance is 0 if the value hits a non-transparent pixel, and +Inf otherwise.
+// So the trap point is the first value of the orbit that hits the image.
+func ImageTrap(im image.Image, center complex128, scale float64) mandelbrot.Trap {
+	return func(z complex128) float64 {
+		p := imageTrapPixel(im, center, scale, z)
+		if !p.In(im.Bounds()) {
+			return math.Inf(1)
+		}
+		if _, _, _, a := im.At(p.X, p.Y).RGBA(); a == 0 {
+			return math.Inf(1)
+		}
+		return 0
+	}
+}
+
+// ImageTrapColorizer returns a [Colorizer] that uses the image as a texture, with [ImageTrap].
+//
+// The color of the image at the trap point is used for points whose orbit hit the image, and the other [Colorizer] is used otherwise.
+// The parameters must be the same as [ImageTrap].
+func ImageTrapColorizer(im image.Image, center complex128, scale float64, other Colorizer) Colorizer {
+	return func(c complex128, res mandelbrot.Result) color.Color {
+		if res.TrapDistance != 0 {
+			return other(c, res)
+		}
+		p := imageTrapPixel(im, center, scale, res.TrapPoint)
+		return im.At(p.X, p.Y)
+	}
+}
+
+func imageTrapPixel(im image.Image, center complex128, scale float64, z complex128) image.Point {
+	bds := im.Bounds()
+	z = (z - center) * complex(scale, 0)
+	return image.Pt(
+		bds.Min.X+bds.Dx()/2+int(math.Floor(real(z))),
+		bds.Min.Y+bds.Dy()/2-int(math.Floor(imag(z))),
+	)
+}
@@ -14,3 +14,10 @@ func MaxIter(scale float64) int {
 func Scale(size image.Point) float64 {
 	return math.Min(float64(size.X), float64(size.Y)) / 4
 }
+
+// Precision returns the precision (in bits) required for the given scale.
+//
+// It is used for the arbitrary-precision coordinates, see [github.com/pierrre/mandelbrot.NewPerturbation].
+func Precision(scale float64) uint {
+	return uint(max(math.Log2(scale), 0)) + 64
+}
@@ -0,0 +1,75 @@
+package mandelbrot
+
+import (
+	"fmt"
+	"math"
+)
+
+// lyapunovBailout is the value of x, above which the orbit diverges.
+const lyapunovBailout = 1e6
+
+// NewLyapunov returns a new [Func] that computes the Lyapunov fractal for the given sequence.
+//
+// The real part of the point is a, and the imaginary part is b.
+// The logistic map x = r*x*(1-x) is iterated from x = 0.5, where r is a or b following the sequence, which is repeated.
+// For example, the sequence "AB" alternates a and b.
+// The first quarter of the iterations (at least 1) is ignored, and the Lyapunov exponent is averaged over the remaining ones.
+// The first iteration is always ignored, because its derivative is 0 at x = 0.5.
+// If there is no remaining iteration, the exponent is 0.
+//
+// The exponent is reported in [Result.Exponent].
+// The [Result] is bounded if the exponent is negative (stable), and unbounded otherwise (chaotic).
+// If the orbit diverges, the exponent is +Inf, and [Result.Iter] is the iteration.
+//
+// It panics if the sequence is empty or contains something else than 'A' and 'B'.
+func NewLyapunov(maxIter int, sequence string) Func {
+	if sequence == "" {
+		panic("empty sequence")
+	}
+	seq := make([]bool, len(sequence))
+	for i, r := range []byte(sequence) {
+		switch r {
+		case 'A':
+		case 'B':
+			seq[i] = true
+		default:
+			panic(fmt.Sprintf("invalid sequence: %q", sequence))
+		}
+	}
+	return func(c complex128) Result {
+		return iterLyapunov(real(c), imag(c), maxIter, seq)
+	}
+}
+
+// iterLyapunov iterates the logistic map, seq is true for b and false for a.
+func iterLyapunov(a, b float64, maxIter int, seq []bool) Result {
+	warmup := max(maxIter/4, 1)
+	x := 0.5
+	var sum float64
+	for iter := range maxIter {
+		r := a
+		if seq[iter%len(seq)] {
+			r = b
+		}
+		if iter >= warmup {
+			sum += math.Log(math.Abs(r * (1 - 2*x)))
+		}
+		x = r * x * (1 - x)
+		if math.Abs(x) > lyapunovBailout {
+			return Result{
+				Bounded:  false,
+				Iter:     iter,
+				Exponent: math.Inf(1),
+			}
+		}
+	}
+	var exponent float64
+	if maxIter > warmup {
+		exponent = sum / float64(maxIter-warmup)
+	}
+	return Result{
+		Bounded:  exponent < 0,
+		Iter:     maxIter,
+		Exponent: exponent,
+	}
+}
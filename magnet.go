@@ -0,0 +1,93 @@
+package mandelbrot
+
+import (
+	"math"
+)
+
+const (
+	magnetBailout       = 100
+	magnetBailoutSquare = magnetBailout * magnetBailout
+)
+
+var logMagnetBailout = math.Log(magnetBailout)
+
+// NewMagnet1 returns a new [Func] that computes the Magnet type I fractal in the parameter plane.
+//
+// The formula is z = ((z^2 + c - 1) / (2*z + c - 2))^2.
+// The orbit starts with z = 0.
+// The point is unbounded if the orbit escapes, and bounded if it converges to the fixed point 1 or reaches the maximum iteration.
+func NewMagnet1(maxIter int) Func {
+	return func(c complex128) Result {
+		return iterMagnet(0, c, maxIter, magnet1)
+	}
+}
+
+// NewMagnet1Julia returns a new [Func] that computes the Magnet type I fractal in the Julia plane, for the constant k.
+//
+// The point is used as the starting value of z, see [NewMagnet1].
+func NewMagnet1Julia(maxIter int, k complex128) Func {
+	return func(c complex128) Result {
+		return iterMagnet(c, k, maxIter, magnet1)
+	}
+}
+
+// NewMagnet2 returns a new [Func] that computes the Magnet type II fractal in the parameter plane.
+//
+// The formula is z = ((z^3 + 3*(c-1)*z + (c-1)*(c-2)) / (3*z^2 + 3*(c-2)*z + (c-1)*(c-2) + 1))^2.
+// The orbit starts with z = 0.
+// The point is unbounded if the orbit escapes, and bounded if it converges to the fixed point 1 or reaches the maximum iteration.
+func NewMagnet2(maxIter int) Func {
+	return func(c complex128) Result {
+		return iterMagnet(0, c, maxIter, magnet2)
+	}
+}
+
+// NewMagnet2Julia returns a new [Func] that computes the Magnet type II fractal in the Julia plane, for the constant k.
+//
+// The point is used as the starting value of z, see [NewMagnet2].
+func NewMagnet2Julia(maxIter int, k complex128) Func {
+	return func(c complex128) Result {
+		return iterMagnet(c, k, maxIter, magnet2)
+	}
+}
+
+func magnet1(z, c complex128) complex128 {
+	w := (z*z + c - 1) / (2*z + c - 2)
+	return w * w
+}
+
+func magnet2(z, c complex128) complex128 {
+	c1 := c - 1
+	c2 := c - 2
+	c12 := c1 * c2
+	z2 := z * z
+	w := (z2*z + 3*c1*z + c12) / (3*z2 + 3*c2*z + c12 + 1)
+	return w * w
+}
+
+func iterMagnet(z, c complex128, maxIter int, step func(z, c complex128) complex128) Result {
+	for iter := range maxIter {
+		absSquare := real(z)*real(z) + imag(z)*imag(z)
+		if absSquare > magnetBailoutSquare {
+			abs := math.Sqrt(absSquare)
+			return Result{
+				Bounded: false,
+				Iter:    iter,
+				Abs:     abs,
+			}
+		}
+		d := z - 1
+		if real(d)*real(d)+imag(d)*imag(d) < newtonTolerance*newtonTolerance {
+			return Result{
+				Bounded: true,
+				Iter:    maxIter,
+				Period:  1,
+			}
+		}
+		z = step(z, c)
+	}
+	return Result{
+		Bounded: true,
+		Iter:    maxIter,
+	}
+}
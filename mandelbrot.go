@@ -3,7 +3,6 @@ package mandelbrot
 
 import (
 	"math"
-	"math/cmplx"
 )
 
 // Func represents a function that computes the Mandelbrot set for a given point.
@@ -14,265 +13,234 @@ type Result struct {
 	Bounded bool
 	Iter    int
 	Abs     float64
-}
+	// Smooth is the normalized (continuous) iteration count of an unbounded point.
+	// It is usually in the range [Iter, Iter+1).
+	// It is never computed by default, because it costs 2 logarithms per point: it requires [Options.Smooth], [Func.WithSmooth] or [BigFunc.WithSmooth].
+	// For the Newton and Nova fractals, it is the fractional number of steps to converge to a root, and it is always computed.
+	Smooth float64
+	// Period is the period of the cycle detected for a bounded point, or 0 if it is unknown.
+	// It requires [Options.Periodicity].
+	Period int
+	// Distance is the estimated distance of an unbounded point to the boundary of the set.
+	// It requires [Options.Distance].
+	Distance float64
+	// Multiplier is the multiplier of the attracting cycle of a bounded point.
+	// Its absolute value is lower than 1 inside a hyperbolic component of the set.
+	// It requires [Options.Interior].
+	Multiplier complex128
+	// InteriorDistance is the estimated distance of a bounded point to the boundary of the set.
+	// It is not computed in the Julia set mode.
+	// It requires [Options.Interior].
+	InteriorDistance float64
+	// TrapDistance is the minimum distance of the orbit to the trap.
+	// It requires [Options.Trap].
+	TrapDistance float64
+	// TrapPoint is the value of the orbit that is the closest to the trap.
+	// It requires [Options.Trap].
+	TrapPoint complex128
+	// TrapIter is the iteration of TrapPoint.
+	// It requires [Options.Trap].
+	TrapIter int
+	// Root is the index of the root that the point converged to, or -1.
+	// It is only used by the Newton fractal, see [NewNewton].
+	Root int
+	// Exponent is the Lyapunov exponent.
+	// It is only used by the Lyapunov fractal, see [NewLyapunov].
+	Exponent float64
+}
+
+const (
+	bailout       = 2
+	bailoutSquare = bailout * bailout
+)
+
+var logBailout = math.Log(bailout)
 
 // New returns a new [Func].
 func New(maxIter int) Func {
-	return newPow2(maxIter)
+	return newPow2(maxIter, 0)
 }
 
 // NewPow returns a new [Func] that uses the given power.
 func NewPow(maxIter int, pow float64) Func {
-	if f, ok := newPows[pow]; ok {
-		return f(maxIter)
+	if pow == 2 {
+		return newPow2(maxIter, 0)
 	}
-	return newPow(maxIter, pow)
+	return newPowFunc(maxIter, pow, 0)
 }
 
-var newPows = map[float64]func(maxIter int) Func{
-	2:  newPow2,
-	3:  newPow3,
-	4:  newPow4,
-	5:  newPow5,
-	6:  newPow6,
-	7:  newPow7,
-	8:  newPow8,
-	9:  newPow9,
-	10: newPow10,
-	11: newPow11,
-	12: newPow12,
-	13: newPow13,
-	14: newPow14,
-	15: newPow15,
-	16: newPow16,
-	17: newPow17,
-	18: newPow18,
-	19: newPow19,
-	20: newPow20,
+// NewJulia returns a new [Func] that computes the Julia set for the constant k.
+//
+// The point is used as the starting value of z, and k is added at each iteration.
+func NewJulia(maxIter int, k complex128) Func {
+	return newJuliaPow2(maxIter, k, 0)
 }
 
-func newPow2(maxIter int) Func {
+// NewJuliaPow returns a new [Func] that computes the Julia set for the constant k and uses the given power.
+func NewJuliaPow(maxIter int, k complex128, pow float64) Func {
+	if pow == 2 {
+		return newJuliaPow2(maxIter, k, 0)
+	}
+	return newJuliaPowFunc(maxIter, k, pow, 0)
+}
+
+func newPow2(maxIter int, periodicity float64) Func {
 	return func(c complex128) Result {
-		// optimization: skip first bulb/cardioid
-		const quarter = 1.0 / 4.0
-		foo := real(c) - quarter
-		imagCSquare := imag(c) * imag(c)
-		q := foo*foo + imagCSquare
-		if q*(q+foo) < imagCSquare*quarter {
+		// optimization: skip main cardioid and period-2 bulb
+		if inPow2Skip(c) {
 			return Result{
 				Bounded: true,
 				Iter:    maxIter,
 			}
 		}
+		return iterPow2(c, c, maxIter, periodicity)
+	}
+}
 
-		z := c
-		for iter := range maxIter {
-			// optimization: calculate "abs square" instead of "abs"
-			absSquare := real(z)*real(z) + imag(z)*imag(z)
-			if absSquare > 4 {
+// inPow2Skip returns true if the point is in the main cardioid or in the period-2 bulb of the Mandelbrot set.
+func inPow2Skip(c complex128) bool {
+	const quarter = 1.0 / 4.0
+	foo := real(c) - quarter
+	imagCSquare := imag(c) * imag(c)
+	q := foo*foo + imagCSquare
+	if q*(q+foo) < imagCSquare*quarter {
+		return true
+	}
+	const sixteenth = 1.0 / 16.0
+	bar := real(c) + 1
+	return bar*bar+imagCSquare < sixteenth
+}
+
+func newJuliaPow2(maxIter int, k complex128, periodicity float64) Func {
+	return func(c complex128) Result {
+		return iterPow2(c, k, maxIter, periodicity)
+	}
+}
+
+func iterPow2(z, c complex128, maxIter int, periodicity float64) Result {
+	per := newPeriodicity(periodicity, z)
+	for iter := range maxIter {
+		// optimization: calculate "abs square" instead of "abs"
+		absSquare := real(z)*real(z) + imag(z)*imag(z)
+		if absSquare > bailoutSquare {
+			abs := math.Sqrt(absSquare)
+			return Result{
+				Bounded: false,
+				Iter:    iter,
+				Abs:     abs,
+			}
+		}
+		z = z*z + c
+		if per.enabled() {
+			if period := per.check(z); period > 0 {
 				return Result{
-					Bounded: false,
-					Iter:    iter,
-					Abs:     math.Sqrt(absSquare),
+					Bounded: true,
+					Iter:    maxIter,
+					Period:  period,
 				}
 			}
-			z = z*z + c
-		}
-		return Result{
-			Bounded: true,
-			Iter:    maxIter,
 		}
 	}
+	return Result{
+		Bounded: true,
+		Iter:    maxIter,
+	}
 }
 
-func newPowFunc(maxIter int, pow func(complex128) complex128) Func {
-	return func(c complex128) Result {
-		z := c
-		for iter := range maxIter {
-			// optimization: calculate "abs square" instead of "abs"
-			absSquare := real(z)*real(z) + imag(z)*imag(z)
-			if absSquare > 4 {
+func newPowFunc(maxIter int, pow float64, periodicity float64) Func {
+	powFunc := getPow(pow)
+	if pow >= 3 && pow == math.Trunc(pow) && pow <= maxPowInt {
+		mc := newMainComponent(int(pow))
+		return func(c complex128) Result {
+			// optimization: skip main component
+			if mc.contains(c) {
 				return Result{
-					Bounded: false,
-					Iter:    iter,
-					Abs:     math.Sqrt(absSquare),
+					Bounded: true,
+					Iter:    maxIter,
 				}
 			}
-			z = pow(z) + c
-		}
-		return Result{
-			Bounded: true,
-			Iter:    maxIter,
+			return iterPow(c, c, maxIter, powFunc, periodicity)
 		}
 	}
+	return func(c complex128) Result {
+		return iterPow(c, c, maxIter, powFunc, periodicity)
+	}
 }
 
-func newPow3(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		return z * z2
-	})
-}
-
-func newPow4(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		return z2 * z2
-	})
-}
-
-func newPow5(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		z4 := z2 * z2
-		return z * z4
-	})
-}
-
-func newPow6(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		z4 := z2 * z2
-		return z2 * z4
-	})
-}
-
-func newPow7(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		z4 := z2 * z2
-		z6 := z2 * z4
-		return z * z6
-	})
-}
-
-func newPow8(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		z4 := z2 * z2
-		return z4 * z4
-	})
-}
-
-func newPow9(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		z4 := z2 * z2
-		z8 := z4 * z4
-		return z * z8
-	})
-}
-
-func newPow10(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		z4 := z2 * z2
-		z8 := z4 * z4
-		return z2 * z8
-	})
-}
-
-func newPow11(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		z4 := z2 * z2
-		z8 := z4 * z4
-		z10 := z2 * z8
-		return z * z10
-	})
-}
-
-func newPow12(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		z4 := z2 * z2
-		z8 := z4 * z4
-		return z4 * z8
-	})
-}
-
-func newPow13(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		z4 := z2 * z2
-		z8 := z4 * z4
-		z12 := z4 * z8
-		return z * z12
-	})
-}
-
-func newPow14(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		z4 := z2 * z2
-		z8 := z4 * z4
-		z12 := z4 * z8
-		return z2 * z12
-	})
-}
-
-func newPow15(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		z4 := z2 * z2
-		z5 := z * z4
-		z10 := z5 * z5
-		return z5 * z10
-	})
-}
-
-func newPow16(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		z4 := z2 * z2
-		z8 := z4 * z4
-		return z8 * z8
-	})
+func newJuliaPowFunc(maxIter int, k complex128, pow float64, periodicity float64) Func {
+	powFunc := getPow(pow)
+	return func(c complex128) Result {
+		return iterPow(c, k, maxIter, powFunc, periodicity)
+	}
 }
 
-func newPow17(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		z4 := z2 * z2
-		z8 := z4 * z4
-		z16 := z8 * z8
-		return z * z16
-	})
+func iterPow(z, c complex128, maxIter int, pow func(complex128) complex128, periodicity float64) Result {
+	per := newPeriodicity(periodicity, z)
+	for iter := range maxIter {
+		// optimization: calculate "abs square" instead of "abs"
+		absSquare := real(z)*real(z) + imag(z)*imag(z)
+		if absSquare > bailoutSquare {
+			abs := math.Sqrt(absSquare)
+			return Result{
+				Bounded: false,
+				Iter:    iter,
+				Abs:     abs,
+			}
+		}
+		z = pow(z) + c
+		if per.enabled() {
+			if period := per.check(z); period > 0 {
+				return Result{
+					Bounded: true,
+					Iter:    maxIter,
+					Period:  period,
+				}
+			}
+		}
+	}
+	return Result{
+		Bounded: true,
+		Iter:    maxIter,
+	}
 }
 
-func newPow18(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		z4 := z2 * z2
-		z8 := z4 * z4
-		z16 := z8 * z8
-		return z2 * z16
-	})
+// smooth returns the normalized iteration count for a point that escaped at the given iteration.
+//
+// It uses the log-log renormalization, so the result doesn't depend on the bailout radius.
+// abs is the value of the norm used by the escape test.
+// logPow is the value returned by [getLogPow].
+// The renormalization is not defined for a power lower than or equal to 1, or an infinite value, so it returns the iteration.
+func smooth(iter int, abs float64, logBailout float64, logPow float64) float64 {
+	if logPow == 0 || math.IsInf(abs, 1) {
+		return float64(iter)
+	}
+	return float64(iter) + 1 - math.Log(math.Log(abs)/logBailout)/logPow
 }
 
-func newPow19(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		z4 := z2 * z2
-		z8 := z4 * z4
-		z16 := z8 * z8
-		z18 := z2 * z16
-		return z * z18
-	})
+// getLogPow returns the logarithm of the power used by [smooth], or 0 if the power is lower than or equal to 1.
+func getLogPow(pow float64) float64 {
+	if pow <= 1 {
+		return 0
+	}
+	return math.Log(pow)
 }
 
-func newPow20(maxIter int) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		z2 := z * z
-		z4 := z2 * z2
-		z8 := z4 * z4
-		z16 := z8 * z8
-		return z4 * z16
-	})
+// withSmooth sets [Result.Smooth] for an unbounded point that escaped with the default bailout.
+func withSmooth(res Result, logPow float64) Result {
+	if !res.Bounded {
+		res.Smooth = smooth(res.Iter, res.Abs, logBailout, logPow)
+	}
+	return res
 }
 
-func newPow(maxIter int, pow float64) Func {
-	return newPowFunc(maxIter, func(z complex128) complex128 {
-		return cmplx.Pow(z, complex(pow, 0))
-	})
+// WithSmooth returns a new [Func] that sets [Result.Smooth] for the unbounded points, with the given power of the formula.
+//
+// It assumes the default bailout.
+// If f uses another bailout, the value is shifted by a constant, and it is still continuous.
+// For a power lower than or equal to 1, it is equal to [Result.Iter].
+func (f Func) WithSmooth(pow float64) Func {
+	logPow := getLogPow(pow)
+	return func(c complex128) Result {
+		return withSmooth(f(c), logPow)
+	}
 }
@@ -1,13 +1,24 @@
 package mandelbrot
 
 import (
+	"math"
+	"math/big"
 	"strconv"
 	"testing"
 )
 
 func BenchmarkNormal(b *testing.B) {
 	f := New(1000)
-	c := complex(-1, 0.15)
+	// The point is in the period-3 bulb, so it is not skipped by the main cardioid and period-2 bulb optimization.
+	c := complex(-0.1, 0.75)
+	for b.Loop() {
+		f(c)
+	}
+}
+
+func BenchmarkJulia(b *testing.B) {
+	f := NewJulia(1000, complex(-0.8, 0.156))
+	c := complex(0.1, 0.1)
 	for b.Loop() {
 		f(c)
 	}
@@ -40,6 +51,233 @@ func BenchmarkPow(b *testing.B) {
 	} {
 		b.Run(strconv.FormatFloat(pow, 'f', -1, 64), func(b *testing.B) {
 			f := NewPow(1000, pow)
+			// The point is just outside the cusp of the main component, so it is not skipped and iterates slowly until the maximum.
+			c := complex((pow-1)/pow*math.Pow(pow, -1/(pow-1))+1e-8, 0)
+			for b.Loop() {
+				f(c)
+			}
+		})
+	}
+}
+
+func BenchmarkOptions(b *testing.B) {
+	for _, norm := range []Norm{
+		NormEuclidean,
+		NormManhattan,
+		NormMax,
+		NormReal,
+		NormImag,
+	} {
+		b.Run(strconv.Itoa(int(norm)), func(b *testing.B) {
+			f := NewWithOptions(Options{
+				MaxIter: 1000,
+				Bailout: 1 << 8,
+				Norm:    norm,
+			})
+			c := complex(-1, 0.15)
+			for b.Loop() {
+				f(c)
+			}
+		})
+	}
+}
+
+func BenchmarkPeriodicity(b *testing.B) {
+	f := NewWithOptions(Options{
+		MaxIter:     1000,
+		Periodicity: DefaultPeriodicity,
+	})
+	// The point is in the period-3 bulb, so it is not skipped by the main cardioid and period-2 bulb optimization.
+	c := complex(-0.1, 0.75)
+	for b.Loop() {
+		f(c)
+	}
+}
+
+func BenchmarkBig(b *testing.B) {
+	f := NewBig(1000, 128)
+	re, im := big.NewFloat(-1), big.NewFloat(0.15)
+	for b.Loop() {
+		f(re, im)
+	}
+}
+
+func BenchmarkPerturbation(b *testing.B) {
+	f := NewPerturbation(1000, 64, big.NewFloat(-1), big.NewFloat(0.15))
+	dc := complex(1e-20, 1e-20)
+	for b.Loop() {
+		f(dc)
+	}
+}
+
+func BenchmarkInterior(b *testing.B) {
+	f := NewWithOptions(Options{
+		MaxIter:  1000,
+		Interior: true,
+	})
+	c := complex(-1, 0.15)
+	for b.Loop() {
+		f(c)
+	}
+}
+
+func BenchmarkOrbit(b *testing.B) {
+	f := NewOrbitFunc(Options{
+		MaxIter: 1000,
+	})
+	c := complex(-1, 0.15)
+	orbit := make([]complex128, 0, 1000)
+	visit := func(iter int, z complex128) {
+		orbit = append(orbit, z)
+	}
+	for b.Loop() {
+		orbit = orbit[:0]
+		f(c, visit)
+	}
+}
+
+func BenchmarkTrap(b *testing.B) {
+	f := NewWithOptions(Options{
+		MaxIter: 1000,
+		Trap:    CrossTrap(0),
+	})
+	c := complex(-1, 0.15)
+	for b.Loop() {
+		f(c)
+	}
+}
+
+func BenchmarkVariant(b *testing.B) {
+	for _, v := range []Variant{
+		VariantBurningShip,
+		VariantTricorn,
+		VariantCeltic,
+		VariantBuffalo,
+		VariantPerpendicular,
+	} {
+		b.Run(strconv.Itoa(int(v)), func(b *testing.B) {
+			f := NewVariant(1000, v, 2)
+			c := complex(-1, 0.15)
+			for b.Loop() {
+				f(c)
+			}
+		})
+	}
+}
+
+func BenchmarkNewton(b *testing.B) {
+	f := NewNewtonCoeffs(1000, []complex128{-1, 0, 0, 1})
+	c := complex(-1, 0.15)
+	for b.Loop() {
+		f(c)
+	}
+}
+
+func BenchmarkPhoenix(b *testing.B) {
+	f := NewPhoenix(1000, complex(-0.5, 0))
+	c := complex(0.1, 0.1)
+	for b.Loop() {
+		f(c)
+	}
+}
+
+func BenchmarkPhoenixJulia(b *testing.B) {
+	f := NewPhoenixJulia(1000, complex(0.5667, 0), complex(-0.5, 0))
+	c := complex(0.1, 0.1)
+	for b.Loop() {
+		f(c)
+	}
+}
+
+func BenchmarkNova(b *testing.B) {
+	f := NewNova(1000, 1)
+	c := complex(-0.5, 0.1)
+	for b.Loop() {
+		f(c)
+	}
+}
+
+func BenchmarkNovaJulia(b *testing.B) {
+	f := NewNovaJulia(1000, complex(-0.5, 0.1), 1)
+	c := complex(0.1, 0.1)
+	for b.Loop() {
+		f(c)
+	}
+}
+
+func BenchmarkMagnet1(b *testing.B) {
+	f := NewMagnet1(1000)
+	c := complex(1.5, 0.5)
+	for b.Loop() {
+		f(c)
+	}
+}
+
+func BenchmarkMagnet1Julia(b *testing.B) {
+	f := NewMagnet1Julia(1000, complex(1.5, 0.5))
+	c := complex(0.1, 0.1)
+	for b.Loop() {
+		f(c)
+	}
+}
+
+func BenchmarkMagnet2(b *testing.B) {
+	f := NewMagnet2(1000)
+	c := complex(1.5, 0.5)
+	for b.Loop() {
+		f(c)
+	}
+}
+
+func BenchmarkMagnet2Julia(b *testing.B) {
+	f := NewMagnet2Julia(1000, complex(1.5, 0.5))
+	c := complex(0.1, 0.1)
+	for b.Loop() {
+		f(c)
+	}
+}
+
+func BenchmarkPolynomial(b *testing.B) {
+	f := NewPolynomial(1000, []complex128{0, -0.5, 0, 1})
+	c := complex(0.1, 0.1)
+	for b.Loop() {
+		f(c)
+	}
+}
+
+func BenchmarkCustom(b *testing.B) {
+	f := NewCustom(1000, func(z, c complex128) complex128 {
+		return z*z + c
+	}, nil)
+	c := complex(-1, 0.15)
+	for b.Loop() {
+		f(c)
+	}
+}
+
+func BenchmarkPowComplex(b *testing.B) {
+	for _, pow := range []complex128{
+		-2,
+		complex(2, 0.5),
+		complex(-1.5, 1),
+	} {
+		b.Run(strconv.FormatComplex(pow, 'f', -1, 128), func(b *testing.B) {
+			f := NewPowComplex(1000, pow)
+			c := complex(0.1, 0.1)
+			for b.Loop() {
+				f(c)
+			}
+		})
+	}
+}
+
+func BenchmarkFunc4D(b *testing.B) {
+	for _, pow := range []float64{2, 3} {
+		b.Run(strconv.FormatFloat(pow, 'f', -1, 64), func(b *testing.B) {
+			f := NewFunc4D(Options{
+				MaxIter: 1000,
+				Power:   pow,
+			}).Slice(NewPlane("xz", complex(0.1, 0.2), 0))
 			c := complex(0.1, 0.1)
 			for b.Loop() {
 				f(c)
@@ -47,3 +285,56 @@ func BenchmarkPow(b *testing.B) {
 		})
 	}
 }
+
+func BenchmarkBatch(b *testing.B) {
+	cs := make([]complex128, 0, 64*64)
+	for y := range 64 {
+		for x := range 64 {
+			cs = append(cs, complex(-1.5+float64(x)*3/64, -1.5+float64(y)*3/64))
+		}
+	}
+	out := make([]Result, len(cs))
+	for _, pow := range []float64{2, 3, 4} {
+		b.Run(strconv.FormatFloat(pow, 'f', -1, 64), func(b *testing.B) {
+			b.Run("Func", func(b *testing.B) {
+				f := NewPow(1000, pow).Batch()
+				for b.Loop() {
+					f(cs, out)
+				}
+			})
+			b.Run("Batch", func(b *testing.B) {
+				f := NewPowBatch(1000, pow)
+				for b.Loop() {
+					f(cs, out)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkTranscendental(b *testing.B) {
+	for _, tc := range []struct {
+		name string
+		f    Func
+	}{
+		{"Exp", NewExp(1000)},
+		{"Sin", NewSin(1000)},
+		{"Cos", NewCos(1000)},
+		{"Lambda", NewLambda(1000)},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			c := complex(0.5, 0.5)
+			for b.Loop() {
+				tc.f(c)
+			}
+		})
+	}
+}
+
+func BenchmarkLyapunov(b *testing.B) {
+	f := NewLyapunov(1000, "AABAB")
+	c := complex(3.4, 3.1)
+	for b.Loop() {
+		f(c)
+	}
+}
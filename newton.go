@@ -0,0 +1,77 @@
+package mandelbrot
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// newtonTolerance is the distance to a root, under which Newton's method converged.
+const newtonTolerance = 1e-6
+
+// NewNewton returns a new [Func] that computes the Newton fractal for the polynomial with the given roots.
+//
+// The point is used as the starting value of Newton's method.
+// If it converges to a root, the [Result] is unbounded, [Result.Root] is the index of the root and [Result.Iter] is the number of steps.
+// Otherwise, the [Result] is bounded and [Result.Root] is -1.
+//
+// It panics if roots is empty.
+func NewNewton(maxIter int, roots []complex128) Func {
+	if len(roots) == 0 {
+		panic("no roots")
+	}
+	roots = append([]complex128(nil), roots...)
+	return func(c complex128) Result {
+		return iterNewton(c, maxIter, roots)
+	}
+}
+
+// NewNewtonCoeffs returns a new [Func] that computes the Newton fractal for the polynomial with the given coefficients.
+//
+// The coefficients are in increasing degree order: coeffs[0] + coeffs[1]*z + coeffs[2]*z^2 + ...
+// The roots are computed with the Durand-Kerner method, see [NewNewton].
+//
+// It panics if the degree of the polynomial is lower than 1.
+func NewNewtonCoeffs(maxIter int, coeffs []complex128) Func {
+	return NewNewton(maxIter, polynomialRoots(coeffs))
+}
+
+// iterNewton runs Newton's method z = z - p(z)/p'(z).
+//
+// p'(z)/p(z) is computed with the roots: sum(1/(z-root)).
+func iterNewton(z complex128, maxIter int, roots []complex128) Result {
+	prev := z
+	for iter := range maxIter {
+		for i, root := range roots {
+			distance := cmplx.Abs(z - root)
+			if distance < newtonTolerance {
+				return Result{
+					Bounded: false,
+					Iter:    iter,
+					Abs:     distance,
+					Smooth:  newtonSmooth(iter, distance, cmplx.Abs(prev-root)),
+					Root:    i,
+				}
+			}
+		}
+		var sum complex128
+		for _, root := range roots {
+			sum += 1 / (z - root)
+		}
+		prev = z
+		z -= 1 / sum
+	}
+	return Result{
+		Bounded: true,
+		Iter:    maxIter,
+		Root:    -1,
+	}
+}
+
+// newtonSmooth returns the fractional number of steps, where the distance to the root crossed the tolerance.
+func newtonSmooth(iter int, distance, prevDistance float64) float64 {
+	if iter == 0 {
+		return 0
+	}
+	t := math.Log(newtonTolerance/prevDistance) / math.Log(distance/prevDistance)
+	return float64(iter-1) + t
+}
@@ -0,0 +1,49 @@
+package mandelbrot
+
+import (
+	"math/cmplx"
+)
+
+// NewNova returns a new [Func] that computes the Nova fractal in the parameter plane.
+//
+// The formula is the relaxed Newton's method for z^3 - 1, plus c: z = z - relax*(z^3-1)/(3*z^2) + c.
+// The orbit starts with z = 1 (a critical point).
+// If the orbit converges, the [Result] is unbounded and [Result.Iter] is the number of steps.
+// Otherwise, the [Result] is bounded.
+func NewNova(maxIter int, relax complex128) Func {
+	return func(c complex128) Result {
+		return iterNova(1, c, relax, maxIter)
+	}
+}
+
+// NewNovaJulia returns a new [Func] that computes the Nova fractal in the Julia plane, for the constant k.
+//
+// The point is used as the starting value of z, see [NewNova].
+func NewNovaJulia(maxIter int, k, relax complex128) Func {
+	return func(c complex128) Result {
+		return iterNova(c, k, relax, maxIter)
+	}
+}
+
+func iterNova(z, c, relax complex128, maxIter int) Result {
+	prevStep := 0.0
+	for iter := range maxIter {
+		z2 := z * z
+		next := z - relax*(z2*z-1)/(3*z2) + c
+		step := cmplx.Abs(next - z)
+		z = next
+		if step < newtonTolerance {
+			return Result{
+				Bounded: false,
+				Iter:    iter,
+				Abs:     step,
+				Smooth:  newtonSmooth(iter, step, prevStep),
+			}
+		}
+		prevStep = step
+	}
+	return Result{
+		Bounded: true,
+		Iter:    maxIter,
+	}
+}
@@ -0,0 +1,355 @@
+package mandelbrot
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// Options represents the options of a [Func].
+//
+// See [NewWithOptions].
+type Options struct {
+	// MaxIter is the maximum number of iterations.
+	// It must not be negative.
+	MaxIter int
+	// Power is the power of z.
+	// The default value is 2.
+	// For a power lower than or equal to 1, [Result.Smooth] is equal to [Result.Iter].
+	Power float64
+	// Bailout is the escape radius.
+	// The default value is 2.
+	// It must be greater than 1.
+	// A larger value gives a more accurate [Result.Smooth] and [Result.Distance].
+	Bailout float64
+	// Smooth enables the normalized iteration count, reported in [Result.Smooth].
+	// It costs 2 logarithms per unbounded point.
+	Smooth bool
+	// Norm is the norm used by the escape test.
+	// The default value is [NormEuclidean].
+	Norm Norm
+	// Julia enables the Julia set mode.
+	// The point is used as the starting value of z, and K is added at each iteration.
+	Julia bool
+	// K is the constant used in the Julia set mode.
+	K complex128
+	// Z0 is the starting value of z, in the Mandelbrot set mode.
+	// The orbit starts with z = Z0^Power + c, so the default value 0 gives the usual z = c.
+	// See [NewFunc4D] to vary it with the point.
+	Z0 complex128
+	// Periodicity is the tolerance used to detect the cycles of the orbit, with Brent's algorithm.
+	// If a cycle is detected, the point is bounded and the iteration stops early.
+	// The detected period is reported in [Result.Period].
+	// 0 disables the detection. [DefaultPeriodicity] is a reasonable value.
+	Periodicity float64
+	// Distance enables the exterior distance estimation, reported in [Result.Distance].
+	// It tracks the derivative of the orbit, which is slower.
+	// A large Bailout value gives a more accurate estimation.
+	Distance bool
+	// Interior enables the computation of the attracting cycle of bounded points.
+	// It reports [Result.Multiplier] and [Result.InteriorDistance].
+	// It requires the periodicity detection, so [DefaultPeriodicity] is used if Periodicity is 0.
+	Interior bool
+	// Trap enables the orbit trap, reported in [Result.TrapDistance], [Result.TrapPoint] and [Result.TrapIter].
+	Trap Trap
+	// Variant is the variant of the formula.
+	// The default value is [VariantMandelbrot].
+	Variant Variant
+}
+
+func (opts Options) withDefaults() Options {
+	if opts.Power == 0 {
+		opts.Power = 2
+	}
+	if opts.Bailout == 0 {
+		opts.Bailout = bailout
+	}
+	if opts.Interior && opts.Periodicity == 0 {
+		opts.Periodicity = DefaultPeriodicity
+	}
+	return opts
+}
+
+// validate panics if the options are invalid.
+func (opts Options) validate() {
+	if opts.MaxIter < 0 {
+		panic(fmt.Sprintf("invalid max iter: %d", opts.MaxIter))
+	}
+	if !(opts.Bailout > 1) {
+		panic(fmt.Sprintf("invalid bailout: %v", opts.Bailout))
+	}
+}
+
+// isDefault returns true if the options can be handled by the optimized [Func].
+func (opts Options) isDefault() bool {
+	return opts.Z0 == 0 && opts.Bailout == bailout && opts.Norm == NormEuclidean && !opts.Distance && !opts.Interior && opts.Trap == nil && opts.Variant == VariantMandelbrot
+}
+
+// NewWithOptions returns a new [Func] for the given [Options].
+//
+// It panics if the options are invalid.
+func NewWithOptions(opts Options) Func {
+	opts = opts.withDefaults()
+	opts.validate()
+	if opts.isDefault() {
+		return newDefaultFunc(opts)
+	}
+	return newOptionsFunc(opts)
+}
+
+func newDefaultFunc(opts Options) Func {
+	f := newDefaultFuncImpl(opts)
+	if !opts.Smooth {
+		return f
+	}
+	logPow := getLogPow(opts.Power)
+	return func(c complex128) Result {
+		return withSmooth(f(c), logPow)
+	}
+}
+
+func newDefaultFuncImpl(opts Options) Func {
+	if opts.Julia {
+		if opts.Power == 2 {
+			return newJuliaPow2(opts.MaxIter, opts.K, opts.Periodicity)
+		}
+		return newJuliaPowFunc(opts.MaxIter, opts.K, opts.Power, opts.Periodicity)
+	}
+	if opts.Power == 2 {
+		return newPow2(opts.MaxIter, opts.Periodicity)
+	}
+	return newPowFunc(opts.MaxIter, opts.Power, opts.Periodicity)
+}
+
+// optionsFunc is the generic implementation of a [Func] for [Options].
+type optionsFunc struct {
+	maxIter     int
+	power       float64
+	pow         func(complex128) complex128
+	powDeriv    func(complex128) complex128
+	logPow      float64
+	smooth      bool
+	norm        func(complex128) float64
+	bailout     float64
+	logBailout  float64
+	julia       bool
+	k           complex128
+	z0          complex128
+	periodicity float64
+	distance    bool
+	interior    bool
+	powDeriv2   func(complex128) complex128
+	trap        Trap
+}
+
+func newOptionsFunc(opts Options) Func {
+	return newOptionsFuncImpl(opts).compute
+}
+
+func newOptionsFuncImpl(opts Options) *optionsFunc {
+	f := &optionsFunc{
+		maxIter:     opts.MaxIter,
+		power:       opts.Power,
+		pow:         opts.Variant.wrap(getPow(opts.Power)),
+		logPow:      getLogPow(opts.Power),
+		smooth:      opts.Smooth,
+		norm:        opts.Norm.normFunc(),
+		bailout:     opts.Bailout,
+		logBailout:  math.Log(opts.Bailout),
+		julia:       opts.Julia,
+		k:           opts.K,
+		z0:          opts.Z0,
+		periodicity: opts.Periodicity,
+		distance:    opts.Distance,
+		interior:    opts.Interior,
+		trap:        opts.Trap,
+	}
+	if f.distance || f.interior {
+		f.powDeriv = getPow(opts.Power - 1)
+	}
+	if f.interior {
+		f.powDeriv2 = getPow(opts.Power - 2)
+	}
+	return f
+}
+
+func (f *optionsFunc) compute(c complex128) Result {
+	return f.iterate(c, nil)
+}
+
+// iterate computes the point, and calls visit for each value of the orbit if it is not nil.
+func (f *optionsFunc) iterate(c complex128, visit OrbitVisitor) Result {
+	z := c
+	if f.julia {
+		c = f.k
+	} else if f.z0 != 0 {
+		z = f.pow(f.z0) + c
+	}
+	return f.iterateFrom(z, c, visit)
+}
+
+// iterateFrom is like iterate, but starts the orbit with z.
+func (f *optionsFunc) iterateFrom(z, c complex128, visit OrbitVisitor) Result {
+	tr := newTrapState()
+	res := f.loop(z, c, visit, &tr)
+	if f.trap != nil {
+		tr.apply(&res)
+	}
+	return res
+}
+
+func (f *optionsFunc) loop(z, c complex128, visit OrbitVisitor, tr *trapState) Result {
+	per := newPeriodicity(f.periodicity, z)
+	// derivative of z, with respect to c (or to the starting value in the Julia set mode)
+	der := complex(1, 0)
+	for iter := range f.maxIter {
+		if visit != nil {
+			visit(iter, z)
+		}
+		if n := f.norm(z); n > f.bailout {
+			return f.escaped(iter, z, n, der)
+		}
+		if f.trap != nil {
+			tr.update(f.trap, iter, z)
+		}
+		if f.distance {
+			der = f.derivative(z, der)
+		}
+		z = f.pow(z) + c
+		if per.enabled() {
+			if period := per.check(z); period > 0 {
+				return f.periodic(z, c, period)
+			}
+		}
+	}
+	return Result{
+		Bounded: true,
+		Iter:    f.maxIter,
+	}
+}
+
+func (f *optionsFunc) escaped(iter int, z complex128, n float64, der complex128) Result {
+	abs := cmplx.Abs(z)
+	res := Result{
+		Bounded: false,
+		Iter:    iter,
+		Abs:     abs,
+	}
+	if f.smooth {
+		res.Smooth = smooth(iter, n, f.logBailout, f.logPow)
+	}
+	if f.distance {
+		// Standard exterior distance estimation: 2*|z|*ln|z|/|dz/dc|.
+		res.Distance = 2 * abs * math.Log(abs) / cmplx.Abs(der)
+	}
+	return res
+}
+
+func (f *optionsFunc) periodic(z, c complex128, period int) Result {
+	res := Result{
+		Bounded: true,
+		Iter:    f.maxIter,
+		Period:  period,
+	}
+	if f.interior {
+		f.computeInterior(&res, z, c)
+	}
+	return res
+}
+
+// computeInterior computes the multiplier and the interior distance of the attracting cycle.
+//
+// z is a value of the orbit that is close to the cycle.
+func (f *optionsFunc) computeInterior(res *Result, z, c complex128) {
+	z = f.refineCycle(z, c, res.Period)
+	power := complex(f.power, 0)
+	// derivatives of the iterated function f^period: d/dz, d/dc, d2/dz2, d2/dcdz
+	dz, dc, dzdz, dcdz := complex(1, 0), complex(0, 0), complex(0, 0), complex(0, 0)
+	for range res.Period {
+		d1 := power * f.powDeriv(z)
+		d2 := power * (power - 1) * f.powDeriv2(z)
+		dcdz = d2*dc*dz + d1*dcdz
+		dzdz = d2*dz*dz + d1*dzdz
+		dz = d1 * dz
+		dc = d1*dc + 1
+		z = f.pow(z) + c
+	}
+	res.Multiplier = dz
+	if !f.julia {
+		res.InteriorDistance = (1 - real(dz)*real(dz) - imag(dz)*imag(dz)) / cmplx.Abs(dcdz+dzdz*dc/(1-dz))
+	}
+}
+
+// refineCycle returns a more accurate value of the cycle, with Newton's method.
+func (f *optionsFunc) refineCycle(z, c complex128, period int) complex128 {
+	const (
+		maxSteps  = 16
+		tolerance = 1e-24
+	)
+	for range maxSteps {
+		w := z
+		d := complex(1, 0)
+		for range period {
+			d = complex(f.power, 0) * f.powDeriv(w) * d
+			w = f.pow(w) + c
+		}
+		step := (w - z) / (d - 1)
+		z -= step
+		if real(step)*real(step)+imag(step)*imag(step) < tolerance {
+			break
+		}
+	}
+	return z
+}
+
+// derivative returns the next value of the derivative.
+func (f *optionsFunc) derivative(z, der complex128) complex128 {
+	der = complex(f.power, 0) * f.powDeriv(z) * der
+	if !f.julia {
+		der++
+	}
+	return der
+}
+
+// Norm represents the norm used by the escape test.
+type Norm int
+
+// Norm values.
+const (
+	// NormEuclidean is the Euclidean norm: sqrt(x^2 + y^2).
+	NormEuclidean Norm = iota
+	// NormManhattan is the Manhattan norm: |x| + |y|.
+	NormManhattan
+	// NormMax is the maximum norm: max(|x|, |y|).
+	NormMax
+	// NormReal only uses the real part: |x|.
+	NormReal
+	// NormImag only uses the imaginary part: |y|.
+	NormImag
+)
+
+func (n Norm) normFunc() func(complex128) float64 {
+	switch n {
+	case NormEuclidean:
+		return func(z complex128) float64 {
+			return math.Sqrt(real(z)*real(z) + imag(z)*imag(z))
+		}
+	case NormManhattan:
+		return func(z complex128) float64 {
+			return math.Abs(real(z)) + math.Abs(imag(z))
+		}
+	case NormMax:
+		return func(z complex128) float64 {
+			return max(math.Abs(real(z)), math.Abs(imag(z)))
+		}
+	case NormReal:
+		return func(z complex128) float64 {
+			return math.Abs(real(z))
+		}
+	case NormImag:
+		return func(z complex128) float64 {
+			return math.Abs(imag(z))
+		}
+	}
+	panic(fmt.Sprintf("invalid norm: %d", n))
+}
@@ -0,0 +1,34 @@
+package mandelbrot
+
+// OrbitVisitor is called for each value of an orbit, before the escape test.
+type OrbitVisitor func(iter int, z complex128)
+
+// OrbitFunc represents a function that computes the Mandelbrot set for a given point, and visits its orbit.
+type OrbitFunc func(c complex128, visit OrbitVisitor) Result
+
+// NewOrbitFunc returns a new [OrbitFunc] for the given [Options].
+//
+// It shares the implementation of [NewWithOptions].
+// If visit is nil, there is no additional cost.
+//
+// It panics if the options are invalid.
+func NewOrbitFunc(opts Options) OrbitFunc {
+	opts = opts.withDefaults()
+	opts.validate()
+	return newOptionsFuncImpl(opts).iterate
+}
+
+// Orbit returns the orbit of the point c for the given power.
+//
+// The first value is c, and the last value is the value that escaped (for an unbounded point).
+func Orbit(c complex128, maxIter int, pow float64) []complex128 {
+	var orbit []complex128
+	f := NewOrbitFunc(Options{
+		MaxIter: maxIter,
+		Power:   pow,
+	})
+	f(c, func(iter int, z complex128) {
+		orbit = append(orbit, z)
+	})
+	return orbit
+}
@@ -0,0 +1,43 @@
+package mandelbrot
+
+// DefaultPeriodicity is a reasonable tolerance for [Options.Periodicity].
+const DefaultPeriodicity = 1e-12
+
+// periodicity detects the cycles of an orbit with Brent's algorithm.
+//
+// The current value of z is saved at iterations that are powers of 2.
+// If a later value is closer than the tolerance, the orbit is considered as periodic.
+type periodicity struct {
+	toleranceSquare float64
+	saved           complex128
+	steps           int
+	limit           int
+}
+
+func newPeriodicity(tolerance float64, z complex128) periodicity {
+	return periodicity{
+		toleranceSquare: tolerance * tolerance,
+		saved:           z,
+		limit:           1,
+	}
+}
+
+// enabled returns true if the periodicity detection is enabled.
+func (p *periodicity) enabled() bool {
+	return p.toleranceSquare > 0
+}
+
+// check returns the period of the orbit if the given value is close to the saved value, or 0.
+func (p *periodicity) check(z complex128) int {
+	p.steps++
+	d := z - p.saved
+	if real(d)*real(d)+imag(d)*imag(d) < p.toleranceSquare {
+		return p.steps
+	}
+	if p.steps == p.limit {
+		p.saved = z
+		p.steps = 0
+		p.limit *= 2
+	}
+	return 0
+}
@@ -0,0 +1,75 @@
+package mandelbrot
+
+import (
+	"math"
+	"math/big"
+)
+
+// NewPerturbation returns a new [Func] that computes the Mandelbrot set with perturbation theory, for deep zooms beyond the float64 precision.
+//
+// The center is an arbitrary-precision point, and the [Func] receives the offset (delta) from it, instead of the point itself.
+// A single reference orbit is computed for the center with [math/big], with the given precision (in bits, at least 64).
+// It must be high enough for the zoom level, see [github.com/pierrre/mandelbrot/image.Precision].
+// The orbit of each point is then computed in float64, as a delta from the reference orbit.
+//
+// When the orbit of a point gets closer to 0 than to the reference orbit, the delta loses its precision (glitch).
+// This is detected, and the delta is rebased on the start of the reference orbit.
+// The same rebasing is done when the reference orbit escapes before the point.
+//
+// The deltas are stored as float64, so the zoom is limited to approximately 1e300.
+func NewPerturbation(maxIter int, prec uint, centerReal, centerImag *big.Float) Func {
+	ref := referenceOrbit(maxIter, max(prec, 64), centerReal, centerImag)
+	return func(dc complex128) Result {
+		return iterPerturbation(ref, dc, maxIter)
+	}
+}
+
+// referenceOrbit computes the orbit of the center, starting with z = 0.
+//
+// It stops when the orbit escapes, or after maxIter+1 iterations.
+func referenceOrbit(maxIter int, prec uint, centerReal, centerImag *big.Float) []complex128 {
+	c := newBigComplex(centerReal, centerImag, prec)
+	z := newBigComplex(new(big.Float), new(big.Float), prec)
+	p := newBigPow2(prec)
+	ref := make([]complex128, 0, maxIter+2)
+	ref = append(ref, 0)
+	for range maxIter + 1 {
+		p.step(z, c)
+		zf := z.complex128()
+		ref = append(ref, zf)
+		if real(zf)*real(zf)+imag(zf)*imag(zf) > bailoutSquare {
+			break
+		}
+	}
+	return ref
+}
+
+func iterPerturbation(ref []complex128, dc complex128, maxIter int) Result {
+	// The first value of the reference orbit is 0, so the orbit of the point starts at ref[1] + dc = center + dc.
+	m := 1
+	dz := dc
+	for iter := range maxIter {
+		z := ref[m] + dz
+		absSquare := real(z)*real(z) + imag(z)*imag(z)
+		if absSquare > bailoutSquare {
+			abs := math.Sqrt(absSquare)
+			return Result{
+				Bounded: false,
+				Iter:    iter,
+				Abs:     abs,
+			}
+		}
+		// rebasing: the reference orbit starts at 0, so the new delta is the value of z
+		if absSquare < real(dz)*real(dz)+imag(dz)*imag(dz) || m == len(ref)-1 {
+			dz = z
+			m = 0
+		}
+		// (Z+dz)^2 + c - (Z^2 + C) = (2*Z + dz)*dz + dc
+		dz = (2*ref[m]+dz)*dz + dc
+		m++
+	}
+	return Result{
+		Bounded: true,
+		Iter:    maxIter,
+	}
+}
@@ -0,0 +1,45 @@
+package mandelbrot
+
+import (
+	"math"
+)
+
+// NewPhoenix returns a new [Func] that computes the Phoenix fractal in the parameter plane.
+//
+// The formula is z = z^2 + c + q*zPrev, where zPrev is the previous value of z.
+// The orbit starts with z = c and zPrev = 0.
+func NewPhoenix(maxIter int, q complex128) Func {
+	return func(c complex128) Result {
+		return iterPhoenix(c, c, q, maxIter)
+	}
+}
+
+// NewPhoenixJulia returns a new [Func] that computes the Phoenix fractal in the Julia plane, for the constant k.
+//
+// The formula is z = z^2 + k + q*zPrev, where zPrev is the previous value of z.
+// The point is used as the starting value of z, and zPrev starts at 0.
+func NewPhoenixJulia(maxIter int, k, q complex128) Func {
+	return func(c complex128) Result {
+		return iterPhoenix(c, k, q, maxIter)
+	}
+}
+
+func iterPhoenix(z, c, q complex128, maxIter int) Result {
+	var prev complex128
+	for iter := range maxIter {
+		absSquare := real(z)*real(z) + imag(z)*imag(z)
+		if absSquare > bailoutSquare {
+			abs := math.Sqrt(absSquare)
+			return Result{
+				Bounded: false,
+				Iter:    iter,
+				Abs:     abs,
+			}
+		}
+		z, prev = z*z+c+q*prev, z
+	}
+	return Result{
+		Bounded: true,
+		Iter:    maxIter,
+	}
+}
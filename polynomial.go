@@ -0,0 +1,114 @@
+package mandelbrot
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// NewPolynomial returns a new [Func] for the polynomial iteration z = coeffs[n]*z^n + ... + coeffs[1]*z + coeffs[0] + c.
+//
+// The coefficients are in increasing degree order.
+// The orbit starts with z = c.
+//
+// It panics if the degree of the polynomial is lower than 2.
+func NewPolynomial(maxIter int, coeffs []complex128) Func {
+	coeffs = trimPolynomial(coeffs)
+	degree := len(coeffs) - 1
+	if degree < 2 {
+		panic("polynomial degree lower than 2")
+	}
+	coeffs = append([]complex128(nil), coeffs...)
+	radius := polynomialEscapeRadius(coeffs)
+	radiusSquare := radius * radius
+	return func(c complex128) Result {
+		z := c
+		for iter := range maxIter {
+			absSquare := real(z)*real(z) + imag(z)*imag(z)
+			if absSquare > radiusSquare {
+				abs := math.Sqrt(absSquare)
+				return Result{
+					Bounded: false,
+					Iter:    iter,
+					Abs:     abs,
+				}
+			}
+			z = polynomialEval(coeffs, z) + c
+		}
+		return Result{
+			Bounded: true,
+			Iter:    maxIter,
+		}
+	}
+}
+
+// polynomialEscapeRadius returns the escape radius for the polynomial.
+//
+// It is at least the default bailout, and grows with the lower degree coefficients.
+func polynomialEscapeRadius(coeffs []complex128) float64 {
+	degree := len(coeffs) - 1
+	sum := 0.0
+	for _, a := range coeffs[:degree] {
+		sum += cmplx.Abs(a)
+	}
+	return max(bailout, (bailout+sum)/cmplx.Abs(coeffs[degree]))
+}
+
+// trimPolynomial removes the zero coefficients of the highest degrees.
+func trimPolynomial(coeffs []complex128) []complex128 {
+	for len(coeffs) > 0 && coeffs[len(coeffs)-1] == 0 {
+		coeffs = coeffs[:len(coeffs)-1]
+	}
+	return coeffs
+}
+
+// polynomialRoots returns the roots of the polynomial, with the Durand-Kerner method.
+func polynomialRoots(coeffs []complex128) []complex128 {
+	coeffs = trimPolynomial(coeffs)
+	degree := len(coeffs) - 1
+	if degree < 1 {
+		panic("polynomial degree lower than 1")
+	}
+	// monic polynomial
+	lead := coeffs[degree]
+	monic := make([]complex128, len(coeffs))
+	for i, a := range coeffs {
+		monic[i] = a / lead
+	}
+	roots := make([]complex128, degree)
+	seed := complex(0.4, 0.9)
+	roots[0] = 1
+	for i := 1; i < degree; i++ {
+		roots[i] = roots[i-1] * seed
+	}
+	const (
+		maxSteps  = 1000
+		tolerance = 1e-24
+	)
+	for range maxSteps {
+		maxStep := 0.0
+		for i, r := range roots {
+			den := complex(1, 0)
+			for j, s := range roots {
+				if j != i {
+					den *= r - s
+				}
+			}
+			step := polynomialEval(monic, r) / den
+			roots[i] = r - step
+			maxStep = max(maxStep, real(step)*real(step)+imag(step)*imag(step))
+		}
+		if maxStep < tolerance {
+			break
+		}
+	}
+	return roots
+}
+
+// polynomialEval evaluates the polynomial with Horner's method.
+func polynomialEval(coeffs []complex128, z complex128) complex128 {
+	var res complex128
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		res = res*z + coeffs[i]
+	}
+	return res
+}
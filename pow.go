@@ -0,0 +1,100 @@
+package mandelbrot
+
+import (
+	"math"
+	"math/bits"
+	"math/cmplx"
+)
+
+// maxPowInt is the maximum absolute value of an integer power that uses exponentiation by squaring.
+const maxPowInt = 1 << 16
+
+// getPow returns a function that computes z^pow.
+//
+// Integer powers use exponentiation by squaring, and other powers use the polar form.
+// The value for z = 0 is the same as [powComplex]: 1 if pow is 0, 0 if pow > 0, and infinity otherwise.
+func getPow(pow float64) func(complex128) complex128 {
+	if pow == math.Trunc(pow) && math.Abs(pow) <= maxPowInt {
+		return newPowInt(int(pow))
+	}
+	return newPowPolar(pow)
+}
+
+// newPowInt returns a function that computes z^n with exponentiation by squaring.
+func newPowInt(n int) func(complex128) complex128 {
+	switch {
+	case n == 0:
+		return pow0
+	case n == 1:
+		return pow1
+	case n == 2:
+		return pow2
+	case n == 3:
+		return pow3
+	case n == 4:
+		return pow4
+	case n < 0:
+		f := newPowInt(-n)
+		return func(z complex128) complex128 {
+			if z == 0 {
+				return cmplx.Inf()
+			}
+			return 1 / f(z)
+		}
+	}
+	// The lowest set bit initializes the result, in order to avoid a multiplication by 1.
+	trailingZeros := bits.TrailingZeros(uint(n))
+	rest := uint(n) >> (trailingZeros + 1)
+	return func(z complex128) complex128 {
+		for range trailingZeros {
+			z *= z
+		}
+		res := z
+		for m := rest; m != 0; m >>= 1 {
+			z *= z
+			if m&1 != 0 {
+				res *= z
+			}
+		}
+		return res
+	}
+}
+
+// newPowPolar returns a function that computes z^pow with the polar form: |z|^pow * e^(i*pow*arg(z)).
+func newPowPolar(pow float64) func(complex128) complex128 {
+	halfPow := pow / 2
+	return func(z complex128) complex128 {
+		absSquare := real(z)*real(z) + imag(z)*imag(z)
+		if absSquare == 0 {
+			if pow < 0 {
+				return cmplx.Inf()
+			}
+			return 0
+		}
+		// optimization: |z|^pow = (|z|^2)^(pow/2)
+		r := math.Pow(absSquare, halfPow)
+		sin, cos := math.Sincos(math.Atan2(imag(z), real(z)) * pow)
+		return complex(r*cos, r*sin)
+	}
+}
+
+func pow0(z complex128) complex128 {
+	return 1
+}
+
+func pow1(z complex128) complex128 {
+	return z
+}
+
+func pow2(z complex128) complex128 {
+	return z * z
+}
+
+func pow3(z complex128) complex128 {
+	return z * z * z
+}
+
+func pow4(z complex128) complex128 {
+	z2 := z * z
+	return z2 * z2
+}
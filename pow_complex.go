@@ -0,0 +1,57 @@
+package mandelbrot
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// NewPowComplex returns a new [Func] that uses the given complex power.
+//
+// It supports negative and complex powers.
+// The singularity at z = 0 is well defined: 0^p is 1 if p is 0, 0 if real(p) > 0, and infinity otherwise.
+//
+// The escape test is |z| > 2 for all powers, and the infinite value is considered as escaped.
+// For a power with a negative real part, infinity is not attracting: the large values of z are mapped close to c.
+// The orbit only escapes if it gets close to the singularity 0, because the next value is then large.
+//
+// [Result.Smooth] is not computed, like [NewPow], see [Func.WithSmooth] with real(p) as the power.
+func NewPowComplex(maxIter int, pow complex128) Func {
+	if imag(pow) == 0 && real(pow) >= 1 {
+		return NewPow(maxIter, real(pow))
+	}
+	return func(c complex128) Result {
+		z := c
+		for iter := range maxIter {
+			// The infinite value is greater than the bailout.
+			absSquare := real(z)*real(z) + imag(z)*imag(z)
+			if absSquare > bailoutSquare {
+				abs := math.Sqrt(absSquare)
+				return Result{
+					Bounded: false,
+					Iter:    iter,
+					Abs:     abs,
+				}
+			}
+			z = powComplex(z, pow) + c
+		}
+		return Result{
+			Bounded: true,
+			Iter:    maxIter,
+		}
+	}
+}
+
+// powComplex computes z^p, with a well-defined value for z = 0.
+func powComplex(z, p complex128) complex128 {
+	if z == 0 {
+		switch {
+		case p == 0:
+			return 1
+		case real(p) > 0:
+			return 0
+		default:
+			return cmplx.Inf()
+		}
+	}
+	return cmplx.Pow(z, p)
+}
@@ -0,0 +1,109 @@
+package mandelbrot
+
+import (
+	"fmt"
+)
+
+// Func4D represents a function that computes a point of the 4D parameter space, for a starting value z0 and a constant c.
+//
+// The Mandelbrot set is the plane z0 = 0, and the Julia set for c is the plane with a fixed c.
+// See [Func4D.Slice] and [Plane] to compute a plane.
+type Func4D func(z0, c complex128) Result
+
+// NewFunc4D returns a new [Func4D] for the given [Options].
+//
+// The orbit starts with z = z0^Power + c.
+// Options.Julia, Options.K and Options.Z0 are ignored.
+// The distance estimation is computed with respect to c.
+//
+// It panics if the options are invalid.
+func NewFunc4D(opts Options) Func4D {
+	opts.Julia = false
+	opts.K = 0
+	opts.Z0 = 0
+	opts = opts.withDefaults()
+	opts.validate()
+	if opts.isDefault() {
+		return newDefaultFunc4D(opts)
+	}
+	f := newOptionsFuncImpl(opts)
+	return func(z0, c complex128) Result {
+		return f.iterateFrom(f.pow(z0)+c, c, nil)
+	}
+}
+
+func newDefaultFunc4D(opts Options) Func4D {
+	maxIter, periodicity := opts.MaxIter, opts.Periodicity
+	var f Func4D
+	if opts.Power == 2 {
+		f = func(z0, c complex128) Result {
+			return iterPow2(z0*z0+c, c, maxIter, periodicity)
+		}
+	} else {
+		powFunc := getPow(opts.Power)
+		f = func(z0, c complex128) Result {
+			return iterPow(powFunc(z0)+c, c, maxIter, powFunc, periodicity)
+		}
+	}
+	if !opts.Smooth {
+		return f
+	}
+	logPow := getLogPow(opts.Power)
+	return func(z0, c complex128) Result {
+		return withSmooth(f(z0, c), logPow)
+	}
+}
+
+// Slice returns a new [Func] that computes the points of the plane.
+func (f Func4D) Slice(plane Plane) Func {
+	return func(p complex128) Result {
+		z0, c := plane(p)
+		return f(z0, c)
+	}
+}
+
+// Plane maps a point to the 4D parameter space.
+//
+// It returns the starting value z0 and the constant c.
+type Plane func(p complex128) (z0, c complex128)
+
+// NewPlane returns a new [Plane] that contains the point (z0, c), and is parallel to the given axes.
+//
+// The axes are named:
+//   - "x": real part of c
+//   - "y": imaginary part of c
+//   - "z": real part of z0
+//   - "w": imaginary part of z0
+//
+// axes contains 2 different axes names.
+// The first axis is mapped to the real part of the point, and the second to the imaginary part.
+// For example, "xy" is the Mandelbrot set plane, "zw" is the Julia set plane, and "xz" or "wy" are perpendicular planes.
+//
+// It panics if axes is invalid.
+func NewPlane(axes string, z0, c complex128) Plane {
+	if len(axes) != 2 || axes[0] == axes[1] {
+		panic(fmt.Sprintf("invalid plane axes: %q", axes))
+	}
+	i, j := planeAxis(axes[0], axes), planeAxis(axes[1], axes)
+	base := [4]float64{real(c), imag(c), real(z0), imag(z0)}
+	return func(p complex128) (complex128, complex128) {
+		v := base
+		v[i] = real(p)
+		v[j] = imag(p)
+		return complex(v[2], v[3]), complex(v[0], v[1])
+	}
+}
+
+func planeAxis(a byte, axes string) int {
+	switch a {
+	case 'x':
+		return 0
+	case 'y':
+		return 1
+	case 'z':
+		return 2
+	case 'w':
+		return 3
+	}
+	panic(fmt.Sprintf("invalid plane axes: %q", axes))
+}
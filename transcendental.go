@@ -0,0 +1,118 @@
+package mandelbrot
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+const (
+	// transcendentalBailout is the bailout of the part of z that grows exponentially.
+	transcendentalBailout = 50
+	lambdaBailout         = 100
+	lambdaBailoutSquare   = lambdaBailout * lambdaBailout
+)
+
+// NewExp returns a new [Func] that computes the exponential map c*exp(z) in the parameter plane.
+//
+// The orbit starts with z = c, the image of the asymptotic value 0.
+// The point is unbounded if the real part of z is greater than 50.
+func NewExp(maxIter int) Func {
+	return func(c complex128) Result {
+		return iterCustom(c, c, maxIter, stepExp, escapeExp)
+	}
+}
+
+// NewExpJulia returns a new [Func] that computes the exponential map k*exp(z) in the Julia plane, for the constant k.
+//
+// The point is used as the starting value of z, see [NewExp].
+func NewExpJulia(maxIter int, k complex128) Func {
+	return func(c complex128) Result {
+		return iterCustom(c, k, maxIter, stepExp, escapeExp)
+	}
+}
+
+func stepExp(z, c complex128) complex128 {
+	return c * cmplx.Exp(z)
+}
+
+func escapeExp(z complex128) bool {
+	return real(z) > transcendentalBailout
+}
+
+// NewSin returns a new [Func] that computes the sine map c*sin(z) in the parameter plane.
+//
+// The orbit starts with z = c, the image of the critical point pi/2.
+// The point is unbounded if the absolute value of the imaginary part of z is greater than 50.
+func NewSin(maxIter int) Func {
+	return func(c complex128) Result {
+		return iterCustom(c, c, maxIter, stepSin, escapeTrigonometric)
+	}
+}
+
+// NewSinJulia returns a new [Func] that computes the sine map k*sin(z) in the Julia plane, for the constant k.
+//
+// The point is used as the starting value of z, see [NewSin].
+func NewSinJulia(maxIter int, k complex128) Func {
+	return func(c complex128) Result {
+		return iterCustom(c, k, maxIter, stepSin, escapeTrigonometric)
+	}
+}
+
+func stepSin(z, c complex128) complex128 {
+	return c * cmplx.Sin(z)
+}
+
+// NewCos returns a new [Func] that computes the cosine map c*cos(z) in the parameter plane.
+//
+// The orbit starts with z = c, the image of the critical point 0.
+// The point is unbounded if the absolute value of the imaginary part of z is greater than 50.
+func NewCos(maxIter int) Func {
+	return func(c complex128) Result {
+		return iterCustom(c, c, maxIter, stepCos, escapeTrigonometric)
+	}
+}
+
+// NewCosJulia returns a new [Func] that computes the cosine map k*cos(z) in the Julia plane, for the constant k.
+//
+// The point is used as the starting value of z, see [NewCos].
+func NewCosJulia(maxIter int, k complex128) Func {
+	return func(c complex128) Result {
+		return iterCustom(c, k, maxIter, stepCos, escapeTrigonometric)
+	}
+}
+
+func stepCos(z, c complex128) complex128 {
+	return c * cmplx.Cos(z)
+}
+
+// escapeTrigonometric is the escape test of the sine and cosine maps, which grow exponentially with the imaginary part of z.
+func escapeTrigonometric(z complex128) bool {
+	return math.Abs(imag(z)) > transcendentalBailout
+}
+
+// NewLambda returns a new [Func] that computes the lambda (logistic) map c*z*(1-z) in the parameter plane.
+//
+// The orbit starts with z = c/4, the image of the critical point 1/2.
+// The point is unbounded if |z| is greater than 100.
+func NewLambda(maxIter int) Func {
+	return func(c complex128) Result {
+		return iterCustom(c/4, c, maxIter, stepLambda, escapeLambda)
+	}
+}
+
+// NewLambdaJulia returns a new [Func] that computes the lambda (logistic) map k*z*(1-z) in the Julia plane, for the constant k.
+//
+// The point is used as the starting value of z, see [NewLambda].
+func NewLambdaJulia(maxIter int, k complex128) Func {
+	return func(c complex128) Result {
+		return iterCustom(c, k, maxIter, stepLambda, escapeLambda)
+	}
+}
+
+func stepLambda(z, c complex128) complex128 {
+	return c * z * (1 - z)
+}
+
+func escapeLambda(z complex128) bool {
+	return real(z)*real(z)+imag(z)*imag(z) > lambdaBailoutSquare
+}
@@ -0,0 +1,72 @@
+package mandelbrot
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Trap represents an orbit trap.
+//
+// It returns the distance of a value of the orbit to the trap.
+type Trap func(z complex128) float64
+
+// PointTrap returns a [Trap] for a point.
+func PointTrap(p complex128) Trap {
+	return func(z complex128) float64 {
+		return cmplx.Abs(z - p)
+	}
+}
+
+// LineTrap returns a [Trap] for a line that goes through p with the given angle (in radians).
+func LineTrap(p complex128, angle float64) Trap {
+	// normal vector of the line
+	sin, cos := math.Sincos(angle)
+	return func(z complex128) float64 {
+		d := z - p
+		return math.Abs(real(d)*sin - imag(d)*cos)
+	}
+}
+
+// CrossTrap returns a [Trap] for a horizontal and a vertical line that go through p.
+//
+// It produces the "Pickover stalks".
+func CrossTrap(p complex128) Trap {
+	return func(z complex128) float64 {
+		d := z - p
+		return min(math.Abs(real(d)), math.Abs(imag(d)))
+	}
+}
+
+// CircleTrap returns a [Trap] for a circle.
+func CircleTrap(center complex128, radius float64) Trap {
+	return func(z complex128) float64 {
+		return math.Abs(cmplx.Abs(z-center) - radius)
+	}
+}
+
+// trapState keeps the minimum distance of the orbit to a [Trap].
+type trapState struct {
+	distance float64
+	point    complex128
+	iter     int
+}
+
+func newTrapState() trapState {
+	return trapState{
+		distance: math.Inf(1),
+	}
+}
+
+func (s *trapState) update(trap Trap, iter int, z complex128) {
+	if d := trap(z); d < s.distance {
+		s.distance = d
+		s.point = z
+		s.iter = iter
+	}
+}
+
+func (s *trapState) apply(res *Result) {
+	res.TrapDistance = s.distance
+	res.TrapPoint = s.point
+	res.TrapIter = s.iter
+}
@@ -0,0 +1,71 @@
+package mandelbrot
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+)
+
+// Variant represents a variant of the formula z = z^p + c.
+//
+// The variants use the absolute values or the conjugate of z, so they are not holomorphic.
+// [Options.Distance] and [Options.Interior] are not accurate with them.
+type Variant int
+
+// Variant values.
+const (
+	// VariantMandelbrot is the standard formula: z = z^p + c.
+	VariantMandelbrot Variant = iota
+	// VariantBurningShip is the Burning Ship: z = (|x| + i|y|)^p + c.
+	VariantBurningShip
+	// VariantTricorn is the Tricorn (Mandelbar): z = conj(z)^p + c.
+	VariantTricorn
+	// VariantCeltic is the Celtic: z = |Re(z^p)| + i*Im(z^p) + c.
+	VariantCeltic
+	// VariantBuffalo is the Buffalo: z = |Re(z^p)| + i|Im(z^p)| + c.
+	VariantBuffalo
+	// VariantPerpendicular is the Perpendicular: z = (|x| - iy)^p + c.
+	VariantPerpendicular
+)
+
+// NewVariant returns a new [Func] that uses the given [Variant] and power.
+//
+// It panics if the variant is invalid.
+func NewVariant(maxIter int, v Variant, pow float64) Func {
+	return NewWithOptions(Options{
+		MaxIter: maxIter,
+		Power:   pow,
+		Variant: v,
+	})
+}
+
+// wrap returns the power function modified for the variant.
+func (v Variant) wrap(pow func(complex128) complex128) func(complex128) complex128 {
+	switch v {
+	case VariantMandelbrot:
+		return pow
+	case VariantBurningShip:
+		return func(z complex128) complex128 {
+			return pow(complex(math.Abs(real(z)), math.Abs(imag(z))))
+		}
+	case VariantTricorn:
+		return func(z complex128) complex128 {
+			return pow(cmplx.Conj(z))
+		}
+	case VariantCeltic:
+		return func(z complex128) complex128 {
+			z = pow(z)
+			return complex(math.Abs(real(z)), imag(z))
+		}
+	case VariantBuffalo:
+		return func(z complex128) complex128 {
+			z = pow(z)
+			return complex(math.Abs(real(z)), math.Abs(imag(z)))
+		}
+	case VariantPerpendicular:
+		return func(z complex128) complex128 {
+			return pow(complex(math.Abs(real(z)), -imag(z)))
+		}
+	}
+	panic(fmt.Sprintf("invalid variant: %d", v))
+}